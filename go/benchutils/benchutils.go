@@ -0,0 +1,87 @@
+//
+// Copyright (C) 2022 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package benchutils implements standardized encoding benchmarks for SysFlow entities.
+package benchutils
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/actgardner/gogen-avro/v7/compiler"
+	"github.com/actgardner/gogen-avro/v7/vm"
+	"github.com/actgardner/gogen-avro/v7/vm/types"
+)
+
+// Benchmarkable is implemented by SysFlow entities that can be exercised by the benchmark helpers.
+// All records generated in package sfgo (e.g., *sfgo.Container) satisfy it.
+type Benchmarkable interface {
+	types.Field
+	Serialize(w io.Writer) error
+	Schema() string
+}
+
+// Factory creates a populated entity to be benchmarked.
+type Factory func() Benchmarkable
+
+// BenchmarkSerialize measures the serialization of the entity created by factory.
+// Allocations and throughput are reported.
+func BenchmarkSerialize(b *testing.B, factory Factory) {
+	e := factory()
+	var buf bytes.Buffer
+	if err := e.Serialize(&buf); err != nil {
+		b.Fatal(err)
+	}
+	b.SetBytes(int64(buf.Len()))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		if err := e.Serialize(&buf); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkDeserialize measures the deserialization of the entity created by factory.
+// The decoding program is compiled once; factory is called once per iteration to obtain
+// the decoding target, so it should be cheap. Allocations and throughput are reported.
+func BenchmarkDeserialize(b *testing.B, factory Factory) {
+	e := factory()
+	var buf bytes.Buffer
+	if err := e.Serialize(&buf); err != nil {
+		b.Fatal(err)
+	}
+	prog, err := compiler.CompileSchemaBytes([]byte(e.Schema()), []byte(e.Schema()))
+	if err != nil {
+		b.Fatal(err)
+	}
+	data := buf.Bytes()
+	r := bytes.NewReader(data)
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.Reset(data)
+		if err := vm.Eval(r, prog, factory()); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package sfgo
+
+import "strings"
+
+// Runtime families used by GeneralizationBucket.
+var runtimeFamilies = map[ContainerType]string{
+	ContainerTypeCT_DOCKER:      "oci",
+	ContainerTypeCT_CRI:         "oci",
+	ContainerTypeCT_CONTAINERD:  "oci",
+	ContainerTypeCT_CRIO:        "oci",
+	ContainerTypeCT_RKT:         "oci",
+	ContainerTypeCT_LXC:         "system",
+	ContainerTypeCT_LIBVIRT_LXC: "system",
+	ContainerTypeCT_MESOS:       "cluster",
+	ContainerTypeCT_BPM:         "cluster",
+	ContainerTypeCT_CUSTOM:      "other",
+}
+
+// GeneralizationBucket returns the key of an aggregation bucket for privacy-preserving reporting,
+// generalizing the container's identity to its runtime family and an image prefix. The runtime
+// type generalizes to one of the families oci (Docker, CRI, containerd, CRI-O, rkt), system (LXC,
+// libvirt LXC), cluster (Mesos, BPM) and other, while the image generalizes according to the
+// generalization level:
+//
+//	0   the repository, e.g., "quay.io/org/app" for "quay.io/org/app:1.0"
+//	1   the repository without its last path element, e.g., "quay.io/org"
+//	2   the registry, e.g., "quay.io" ("docker.io" for images without registry)
+//	3+  nothing, bucketing by runtime family alone
+//
+// Negative levels count as 0, and NA, empty and unparseable images generalize to "unknown".
+// Since a single record cannot tell how populated its bucket is, callers enforce k-anonymity by
+// raising the level until every bucket of their population holds at least k containers.
+func (r *Container) GeneralizationBucket(level int) string {
+	family, ok := runtimeFamilies[r.Type]
+	if !ok {
+		family = "other"
+	}
+	if level >= 3 {
+		return family
+	}
+	return family + "|" + generalizeImage(r.Image, level)
+}
+
+// generalizeImage generalizes the image to a level in [0, 2] (see GeneralizationBucket).
+func generalizeImage(image string, level int) string {
+	if isAbsent(image) {
+		return "unknown"
+	}
+	ref, err := ParseImageRef(image)
+	if err != nil {
+		return "unknown"
+	}
+	registry := strings.ToLower(ref.Registry)
+	if registry == "" {
+		registry = defaultRegistryHost
+	}
+	switch {
+	case level >= 2:
+		return registry
+	case level == 1:
+		if i := strings.LastIndexByte(ref.Path, '/'); i >= 0 {
+			return registry + "/" + ref.Path[:i]
+		}
+		return registry
+	}
+	return registry + "/" + ref.Path
+}
@@ -0,0 +1,70 @@
+package sfgo
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// ContainerAnonymizer deterministically pseudonymizes identifying container attributes, so that
+// equal inputs map to equal outputs and joins on the pseudonyms remain possible.
+type ContainerAnonymizer struct {
+	salt []byte
+}
+
+// NewContainerAnonymizer creates an anonymizer keyed with salt. Pseudonyms are HMAC-SHA256
+// digests under salt, so they cannot be reversed or recomputed without it.
+func NewContainerAnonymizer(salt []byte) *ContainerAnonymizer {
+	return &ContainerAnonymizer{salt: append([]byte(nil), salt...)}
+}
+
+// Anonymize returns a copy of the container with pseudonymized id, name, image, image id and pod id.
+// Ids keep their length (up to 64 hex characters), so hex ids remain valid; the type and the
+// privileged flag are kept, and empty or NA values are left untouched.
+func (a *ContainerAnonymizer) Anonymize(r *Container) *Container {
+	c := &Container{
+		Id:         a.AnonymizeID(r.Id),
+		Name:       a.pseudonym("name", r.Name, "container-", 12),
+		Image:      a.pseudonym("image", r.Image, "image-", 12),
+		Imageid:    a.pseudonym("imageid", r.Imageid, "", len(r.Imageid)),
+		Type:       r.Type,
+		Privileged: r.Privileged,
+	}
+	if r.PodId != nil {
+		p := *r.PodId
+		p.String = a.pseudonym("podId", p.String, "pod-", 12)
+		c.PodId = &p
+	}
+	return c
+}
+
+// AnonymizeID pseudonymizes a container id as Anonymize does, e.g., for the container ids
+// referenced by other SysFlow entities.
+func (a *ContainerAnonymizer) AnonymizeID(id string) string {
+	return a.pseudonym("id", id, "", len(id))
+}
+
+// pseudonym derives a pseudonym of value in the given field's domain, consisting of prefix and
+// n hex characters (between 1 and 64).
+func (a *ContainerAnonymizer) pseudonym(field, value, prefix string, n int) string {
+	if value == "" || value == naValue {
+		return value
+	}
+	mac := hmac.New(sha256.New, a.salt)
+	mac.Write([]byte(field))
+	mac.Write([]byte{0})
+	mac.Write([]byte(value))
+	sum := hex.EncodeToString(mac.Sum(nil))
+	if n > len(sum) {
+		n = len(sum)
+	}
+	return prefix + sum[:n]
+}
+
+// IdentityOnly returns a copy of the container holding only its identity fields, Id, Image and
+// Imageid, for contexts where the remaining attributes must not leak. All other fields are zeroed:
+// Name is empty, Privileged false and PodId null, and Type is the enum's zero value (CT_DOCKER),
+// so it carries no information.
+func (r *Container) IdentityOnly() *Container {
+	return &Container{Id: r.Id, Image: r.Image, Imageid: r.Imageid}
+}
@@ -0,0 +1,61 @@
+package sfgo
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// ContainerAVSC returns the container schema pretty-printed as an .avsc document, e.g., for
+// registration with a schema registry. It has the same content as Schema().
+func ContainerAVSC() string {
+	return formatAVSC(false)
+}
+
+// ContainerAVSCNormalized is like ContainerAVSC, but splits the full names of named types into a
+// simple name and an explicit namespace, as expected by some registries and tools.
+func ContainerAVSCNormalized() string {
+	return formatAVSC(true)
+}
+
+func formatAVSC(normalize bool) string {
+	dec := json.NewDecoder(strings.NewReader(NewContainer().Schema()))
+	dec.UseNumber()
+	var s interface{}
+	if err := dec.Decode(&s); err != nil {
+		panic(err)
+	}
+	if normalize {
+		normalizeNamespaces(s)
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(s); err != nil {
+		panic(err)
+	}
+	return buf.String()
+}
+
+// normalizeNamespaces rewrites dotted names of named types into name and namespace attributes.
+func normalizeNamespaces(s interface{}) {
+	switch v := s.(type) {
+	case map[string]interface{}:
+		switch v["type"] {
+		case "record", "enum", "fixed":
+			if name, ok := v["name"].(string); ok {
+				if i := strings.LastIndex(name, "."); i >= 0 {
+					v["name"], v["namespace"] = name[i+1:], name[:i]
+				}
+			}
+		}
+		for _, e := range v {
+			normalizeNamespaces(e)
+		}
+	case []interface{}:
+		for _, e := range v {
+			normalizeNamespaces(e)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package sfgo
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/actgardner/gogen-avro/v7/vm"
+)
+
+// maxBatchPrealloc bounds the number of containers preallocated from a batch's declared count, so
+// that a corrupted count cannot exhaust memory before any record is read.
+const maxBatchPrealloc = 1024
+
+// maxBatchRecordSize bounds the length of a batched record, so that a corrupted length cannot
+// trigger a huge allocation.
+const maxBatchRecordSize = 1 << 20
+
+// SerializeContainerBatch writes the containers as a single self-delimited frame:
+//
+//	count    long (Avro zig-zag varint), the number of records, 0 for an empty batch
+//	records  count times: length long, followed by the record's binary encoding of that length
+//
+// The frame can be read with DeserializeContainerBatch.
+func SerializeContainerBatch(w io.Writer, cs []*Container) error {
+	bw := bufio.NewWriter(w)
+	if err := vm.WriteLong(int64(len(cs)), bw); err != nil {
+		return err
+	}
+	var buf []byte
+	for _, c := range cs {
+		var err error
+		if buf, err = c.AppendBinary(buf[:0]); err != nil {
+			return err
+		}
+		if err := vm.WriteLong(int64(len(buf)), bw); err != nil {
+			return err
+		}
+		if _, err := bw.Write(buf); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// DeserializeContainerBatch reads a frame written with SerializeContainerBatch. It reads exactly
+// the frame from r if r implements io.ByteReader, and may read ahead otherwise. Truncated frames
+// yield an ErrTruncated error, and records longer than 1 MiB or not filling their length exactly
+// an ErrDecode error. Registered enrichers run on each record (see RegisterContainerEnricher).
+func DeserializeContainerBatch(r io.Reader) ([]*Container, error) {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		b := bufio.NewReader(r)
+		r, br = b, b
+	}
+	count, err := readLong(br)
+	if err != nil {
+		return nil, newDecodeError(fmt.Errorf("reading batch count: %w", err))
+	}
+	if count < 0 {
+		return nil, &Error{Kind: ErrDecode, Err: fmt.Errorf("invalid batch count %d", count)}
+	}
+	prealloc := count
+	if prealloc > maxBatchPrealloc {
+		prealloc = maxBatchPrealloc
+	}
+	cs := make([]*Container, 0, prealloc)
+	var buf []byte
+	for i := int64(0); i < count; i++ {
+		n, err := readLong(br)
+		if err != nil {
+			return nil, newDecodeError(fmt.Errorf("reading length of record %d: %w", i, err))
+		}
+		if n < 0 || n > maxBatchRecordSize {
+			return nil, &Error{Kind: ErrDecode, Err: fmt.Errorf("invalid length %d of record %d", n, i)}
+		}
+		if int64(cap(buf)) < n {
+			buf = make([]byte, n)
+		}
+		buf = buf[:n]
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, newDecodeError(fmt.Errorf("reading record %d: %w", i, err))
+		}
+		c, m, err := DeserializeContainerBytes(buf)
+		if err != nil {
+			return nil, newDecodeError(fmt.Errorf("decoding record %d: %w", i, err))
+		}
+		if m != len(buf) {
+			return nil, &Error{Kind: ErrDecode, Err: fmt.Errorf("%d unexpected bytes after record %d", len(buf)-m, i)}
+		}
+		if err := enrichContainer(c); err != nil {
+			return nil, err
+		}
+		cs = append(cs, c)
+	}
+	return cs, nil
+}
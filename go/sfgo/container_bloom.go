@@ -0,0 +1,121 @@
+package sfgo
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+)
+
+// IdentityHash returns a 64-bit FNV-1a hash of the container's identity, i.e., its runtime
+// type and id.
+func (r *Container) IdentityHash() uint64 {
+	h := fnv.New64a()
+	var t [4]byte
+	binary.LittleEndian.PutUint32(t[:], uint32(r.Type))
+	h.Write(t[:])
+	h.Write([]byte(r.Id))
+	return h.Sum64()
+}
+
+// defaultBloomFPRate is the false positive rate used for invalid rates.
+const defaultBloomFPRate = 0.01
+
+// maxBloomHashes bounds the number of hash functions of decoded filters; optimal filters need
+// about 30 even for false positive rates as low as 1e-9.
+const maxBloomHashes = 64
+
+// ContainerBloom is a Bloom filter over container identities (see IdentityHash).
+// It can be shared between processes using MarshalBinary and UnmarshalBinary.
+type ContainerBloom struct {
+	k    uint32
+	bits []uint64
+}
+
+// BuildContainerBloom builds a Bloom filter over the containers sized for the false positive
+// rate fpRate (0.01 if not in (0, 1)).
+func BuildContainerBloom(cs []*Container, fpRate float64) *ContainerBloom {
+	if !(fpRate > 0 && fpRate < 1) {
+		fpRate = defaultBloomFPRate
+	}
+	n := math.Max(float64(len(cs)), 1)
+	m := math.Ceil(-n * math.Log(fpRate) / (math.Ln2 * math.Ln2))
+	k := math.Min(math.Max(math.Round(m/n*math.Ln2), 1), maxBloomHashes)
+	b := &ContainerBloom{k: uint32(k), bits: make([]uint64, (uint64(m)+63)/64)}
+	for _, c := range cs {
+		b.Add(c)
+	}
+	return b
+}
+
+// Add adds the container's identity to the filter. It is a no-op on the zero filter, which has
+// no bits to set.
+func (b *ContainerBloom) Add(c *Container) {
+	if len(b.bits) == 0 {
+		return
+	}
+	h1, h2 := bloomHashes(c)
+	m := uint64(len(b.bits)) * 64
+	for i := uint64(0); i < uint64(b.k); i++ {
+		bit := (h1 + i*h2) % m
+		b.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+// MayContain checks whether the container's identity may have been added to the filter.
+// False positives occur at about the rate the filter was built for; false negatives never occur.
+// The zero filter contains nothing.
+func (b *ContainerBloom) MayContain(c *Container) bool {
+	if len(b.bits) == 0 {
+		return false
+	}
+	h1, h2 := bloomHashes(c)
+	m := uint64(len(b.bits)) * 64
+	for i := uint64(0); i < uint64(b.k); i++ {
+		bit := (h1 + i*h2) % m
+		if b.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// bloomHashes derives the two hashes used for double hashing from the identity hash.
+func bloomHashes(c *Container) (uint64, uint64) {
+	h := c.IdentityHash()
+	// splitmix64 finalizer, to decorrelate the second hash from the first.
+	z := h + 0x9e3779b97f4a7c15
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	z ^= z >> 31
+	return h, z | 1
+}
+
+// MarshalBinary encodes the filter as the little-endian number of hash functions (uint32)
+// followed by the little-endian 64-bit words of the bit array.
+func (b *ContainerBloom) MarshalBinary() ([]byte, error) {
+	out := make([]byte, 4+8*len(b.bits))
+	binary.LittleEndian.PutUint32(out, b.k)
+	for i, w := range b.bits {
+		binary.LittleEndian.PutUint64(out[4+8*i:], w)
+	}
+	return out, nil
+}
+
+// UnmarshalBinary decodes a filter encoded with MarshalBinary.
+func (b *ContainerBloom) UnmarshalBinary(data []byte) error {
+	if len(data) < 12 || (len(data)-4)%8 != 0 {
+		return errors.New("invalid container bloom filter encoding")
+	}
+	k := binary.LittleEndian.Uint32(data)
+	if k == 0 || k > maxBloomHashes {
+		return fmt.Errorf("invalid container bloom filter encoding: %d hash functions", k)
+	}
+	bits := make([]uint64, (len(data)-4)/8)
+	for i := range bits {
+		bits[i] = binary.LittleEndian.Uint64(data[4+8*i:])
+	}
+	b.k, b.bits = k, bits
+	return nil
+}
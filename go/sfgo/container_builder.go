@@ -0,0 +1,67 @@
+package sfgo
+
+// ContainerBuilder builds validated containers using chained setters.
+type ContainerBuilder struct {
+	c Container
+}
+
+// NewContainerBuilder creates a new container builder.
+func NewContainerBuilder() *ContainerBuilder {
+	return &ContainerBuilder{}
+}
+
+// ID sets the container id.
+func (b *ContainerBuilder) ID(id string) *ContainerBuilder {
+	b.c.Id = id
+	return b
+}
+
+// Name sets the container name.
+func (b *ContainerBuilder) Name(name string) *ContainerBuilder {
+	b.c.Name = name
+	return b
+}
+
+// Image sets the container image.
+func (b *ContainerBuilder) Image(image string) *ContainerBuilder {
+	b.c.Image = image
+	return b
+}
+
+// ImageID sets the container image id.
+func (b *ContainerBuilder) ImageID(imageid string) *ContainerBuilder {
+	b.c.Imageid = imageid
+	return b
+}
+
+// Type sets the container runtime type.
+func (b *ContainerBuilder) Type(t ContainerType) *ContainerBuilder {
+	b.c.Type = t
+	return b
+}
+
+// Privileged sets the container privileged flag.
+func (b *ContainerBuilder) Privileged(privileged bool) *ContainerBuilder {
+	b.c.Privileged = privileged
+	return b
+}
+
+// PodID sets the id of the pod running the container.
+func (b *ContainerBuilder) PodID(podID string) *ContainerBuilder {
+	b.c.PodId = &PodIdUnion{String: podID, UnionType: PodIdUnionTypeEnumString}
+	return b
+}
+
+// Build validates and returns a copy of the container being built.
+// The returned error lists all validation problems at once.
+func (b *ContainerBuilder) Build() (*Container, error) {
+	c := b.c
+	if c.PodId != nil {
+		p := *c.PodId
+		c.PodId = &p
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
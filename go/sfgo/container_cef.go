@@ -0,0 +1,60 @@
+package sfgo
+
+import "strings"
+
+// CEF escapers for header fields and extension values.
+var (
+	cefHeaderEscaper    = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\r", " ", "\n", " ")
+	cefExtensionEscaper = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\r", `\r`, "\n", `\n`)
+)
+
+// CEF severities of container observations.
+const (
+	cefSeverity           = "3"
+	cefSeverityPrivileged = "5"
+)
+
+// CEF returns the container observation as an ArcSight Common Event Format line (without syslog
+// prefix), e.g.,
+//
+//	CEF:0|vendor|product|1.0|CT_DOCKER|Container observed|3|cs1Label=containerId cs1=...
+//
+// The device event class id is the container type's enum symbol, and the severity is 3, or 5 for
+// privileged containers. The container fields are mapped to the custom extensions cs1 (id), cs2
+// (name), cs3 (image), cs4 (image id), cs5 (pod id), cs6 (runtime, see RuntimeName) and cn1
+// (privileged, 0 or 1), each with its label; empty and NA values are omitted. Header fields are
+// escaped for | and \, extension values for =, \ and line breaks.
+func (r *Container) CEF(deviceVendor, deviceProduct, deviceVersion string) string {
+	var b strings.Builder
+	severity := cefSeverity
+	if r.Privileged {
+		severity = cefSeverityPrivileged
+	}
+	b.WriteString("CEF:0")
+	for _, h := range []string{deviceVendor, deviceProduct, deviceVersion, r.Type.String(), "Container observed", severity} {
+		b.WriteString("|" + cefHeaderEscaper.Replace(h))
+	}
+	b.WriteString("|")
+	sep := ""
+	ext := func(key, label, value string) {
+		if isAbsent(value) {
+			return
+		}
+		b.WriteString(sep + key + "Label=" + label + " " + key + "=" + cefExtensionEscaper.Replace(value))
+		sep = " "
+	}
+	ext("cs1", "containerId", r.Id)
+	ext("cs2", "containerName", r.Name)
+	ext("cs3", "containerImage", r.Image)
+	ext("cs4", "containerImageId", r.Imageid)
+	if r.PodId != nil {
+		ext("cs5", "podId", r.PodId.String)
+	}
+	ext("cs6", "containerRuntime", r.Type.RuntimeName())
+	privileged := "0"
+	if r.Privileged {
+		privileged = "1"
+	}
+	ext("cn1", "privileged", privileged)
+	return b.String()
+}
@@ -0,0 +1,51 @@
+package sfgo
+
+import (
+	"bufio"
+	"context"
+	"io"
+)
+
+// DeserializeContainersChan decodes a stream of concatenated binary-encoded containers from r on
+// a separate goroutine, sending them to the returned (unbuffered) container channel until the end
+// of the stream, a decoding error, or the cancellation of ctx. A decoding error or ctx.Err() is
+// sent to the error channel; both channels are closed when decoding stops. Since r is read
+// through a buffer, its position is unspecified once decoding stops.
+func DeserializeContainersChan(ctx context.Context, r io.Reader) (<-chan *Container, <-chan error) {
+	cs := make(chan *Container)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		defer close(cs)
+		prog, err := getContainerProgram()
+		if err != nil {
+			errs <- err
+			return
+		}
+		br := bufio.NewReader(r)
+		for {
+			if err := ctx.Err(); err != nil {
+				errs <- err
+				return
+			}
+			t := NewContainer()
+			if err := evalContainer(br, prog, t); err == io.EOF {
+				return
+			} else if err != nil {
+				errs <- newDecodeError(err)
+				return
+			}
+			if err := enrichContainer(t); err != nil {
+				errs <- err
+				return
+			}
+			select {
+			case cs <- t:
+			case <-ctx.Done():
+				errs <- ctx.Err()
+				return
+			}
+		}
+	}()
+	return cs, errs
+}
@@ -0,0 +1,48 @@
+package sfgo
+
+import (
+	"bufio"
+	"io"
+	"sort"
+)
+
+// CompactContainerLog compacts an append-only log of concatenated binary-encoded containers,
+// writing to w only the latest record of each key, in the order in which these latest records
+// appear in the log. History is not preserved: earlier records of a key are dropped even if they
+// differ from the latest one. The log is read in a single pass, decoding each record; the latest
+// record of each key is kept in memory until the end of the log and then re-serialized, so memory
+// use grows with the number of distinct keys, not the length of the log. Records are re-encoded
+// rather than copied byte for byte, and the registered enrichers are not run.
+func CompactContainerLog(r io.Reader, w io.Writer, key func(*Container) string) error {
+	prog, err := getContainerProgram()
+	if err != nil {
+		return err
+	}
+	type entry struct {
+		seq int
+		c   *Container
+	}
+	latest := make(map[string]entry)
+	br := bufio.NewReader(r)
+	for seq := 0; ; seq++ {
+		t := NewContainer()
+		if err := evalContainer(br, prog, t); err == io.EOF {
+			break
+		} else if err != nil {
+			return newDecodeError(err)
+		}
+		latest[key(t)] = entry{seq: seq, c: t}
+	}
+	entries := make([]entry, 0, len(latest))
+	for _, e := range latest {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].seq < entries[j].seq })
+	bw := bufio.NewWriter(w)
+	for _, e := range entries {
+		if err := e.c.Serialize(bw); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
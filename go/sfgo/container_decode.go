@@ -0,0 +1,178 @@
+package sfgo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"runtime/debug"
+	"sync"
+
+	"github.com/actgardner/gogen-avro/v7/compiler"
+	"github.com/actgardner/gogen-avro/v7/vm"
+)
+
+var (
+	contProg     *vm.Program
+	contProgErr  error
+	contProgOnce sync.Once
+)
+
+// getContainerProgram returns the compiled (non-resolving) container decoding program.
+func getContainerProgram() (*vm.Program, error) {
+	contProgOnce.Do(func() {
+		t := NewContainer()
+		contProg, contProgErr = compiler.CompileSchemaBytes([]byte(t.Schema()), []byte(t.Schema()))
+		if contProgErr != nil {
+			contProgErr = newCompileError(contProgErr)
+		}
+	})
+	return contProg, contProgErr
+}
+
+// DeserializeContainerCached decodes a container from r like the generated DeserializeContainer,
+// but compiles the decoding program only once and returns errors wrapping the package's sentinel
+// errors, e.g., ErrTruncated for input ending within the record. A clean end of input is returned
+// as io.EOF.
+func DeserializeContainerCached(r io.Reader) (*Container, error) {
+	deser, err := getContainerProgram()
+	if err != nil {
+		return nil, err
+	}
+	t := NewContainer()
+	if err := evalContainer(r, deser, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// DeserializeContainerWithRaw decodes a container from r and returns the exact bytes consumed to decode it.
+func DeserializeContainerWithRaw(r io.Reader) (*Container, []byte, error) {
+	deser, err := getContainerProgram()
+	if err != nil {
+		return nil, nil, err
+	}
+	var raw bytes.Buffer
+	t := NewContainer()
+	if err = evalContainer(io.TeeReader(r, &raw), deser, t); err != nil {
+		return nil, raw.Bytes(), err
+	}
+	return t, raw.Bytes(), nil
+}
+
+// evalContainer runs a container decoding program over r.
+func evalContainer(r io.Reader, prog *vm.Program, t *Container) error {
+	return evalEntity(r, prog, t)
+}
+
+// DeserializeContainerBytes decodes a container from the beginning of b and returns the number of bytes consumed.
+// Bytes following the record are never treated as an error; callers can process b[n:] themselves.
+func DeserializeContainerBytes(b []byte) (*Container, int, error) {
+	deser, err := getContainerProgram()
+	if err != nil {
+		return nil, 0, err
+	}
+	r := bytes.NewReader(b)
+	t := NewContainer()
+	err = evalContainer(r, deser, t)
+	n := len(b) - r.Len()
+	if err != nil {
+		return nil, n, err
+	}
+	return t, n, nil
+}
+
+// DeserializeContainerAt decodes the container starting at offset in data, e.g., a memory-mapped
+// file, and returns the offset following it. Decoded strings are always copied, never aliasing
+// data, so containers stay valid after the region is unmapped.
+func DeserializeContainerAt(data []byte, offset int) (*Container, int, error) {
+	if offset < 0 || offset > len(data) {
+		return nil, offset, &Error{Kind: ErrDecode, Err: fmt.Errorf("offset %d out of range [0, %d]", offset, len(data))}
+	}
+	c, n, err := DeserializeContainerBytes(data[offset:])
+	return c, offset + n, err
+}
+
+// DeserializeContainerChecked decodes a container from r while computing a checksum over the consumed
+// bytes, and returns an ErrCRCMismatch error if it differs from expected. The checksum is computed
+// with h, or with CRC32C (Castagnoli) if h is nil.
+func DeserializeContainerChecked(r io.Reader, expected uint32, h hash.Hash32) (*Container, error) {
+	deser, err := getContainerProgram()
+	if err != nil {
+		return nil, err
+	}
+	if h == nil {
+		h = crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	}
+	t := NewContainer()
+	if err = evalContainer(io.TeeReader(r, h), deser, t); err != nil {
+		return nil, err
+	}
+	if sum := h.Sum32(); sum != expected {
+		return nil, &Error{Kind: ErrCRCMismatch, Err: fmt.Errorf("got %08x, expected %08x", sum, expected)}
+	}
+	return t, nil
+}
+
+// SafeDeserializeContainer decodes a container from untrusted input, converting any panic raised
+// while decoding into an ErrDecode error carrying the panic value and stack trace. Container types
+// outside the schema's symbols, which the generated decoder accepts, are rejected with ErrDecode.
+func SafeDeserializeContainer(r io.Reader) (c *Container, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			c, err = nil, &Error{Kind: ErrDecode, Err: fmt.Errorf("panic while decoding container: %v\n%s", p, debug.Stack())}
+		}
+	}()
+	if r == nil {
+		return nil, &Error{Kind: ErrDecode, Err: errors.New("nil reader")}
+	}
+	prog, err := getContainerProgram()
+	if err != nil {
+		return nil, err
+	}
+	c = NewContainer()
+	if err := evalContainer(r, prog, c); err != nil {
+		return nil, newDecodeError(err)
+	}
+	if c.Type < ContainerTypeCT_DOCKER || c.Type > ContainerTypeCT_BPM {
+		return nil, &Error{Kind: ErrDecode, Err: fmt.Errorf("container type index %d out of range", int32(c.Type))}
+	}
+	return c, nil
+}
+
+// DeserializeFirstNContainers decodes up to n containers from a stream of concatenated
+// binary-encoded containers, returning fewer at the end of the stream. Unlike the buffered stream
+// decoders, it reads r without read-ahead, so r is positioned right after the last consumed record
+// and the rest of the stream is never touched. Reading unbuffered is slow for readers that are not
+// io.ByteReaders (e.g., files), since each varint costs a read call; wrap those in a bufio.Reader
+// and keep reading from it. On a decoding error the containers decoded so far are returned along
+// with the error.
+func DeserializeFirstNContainers(r io.Reader, n int) ([]*Container, error) {
+	prog, err := getContainerProgram()
+	if err != nil {
+		return nil, err
+	}
+	prealloc := n
+	if prealloc > maxBatchPrealloc {
+		prealloc = maxBatchPrealloc
+	}
+	var cs []*Container
+	if prealloc > 0 {
+		cs = make([]*Container, 0, prealloc)
+	}
+	for len(cs) < n {
+		t := NewContainer()
+		if err := evalContainer(r, prog, t); err == io.EOF {
+			break
+		} else if err != nil {
+			return cs, newDecodeError(err)
+		}
+		if err := enrichContainer(t); err != nil {
+			return cs, err
+		}
+		cs = append(cs, t)
+	}
+	return cs, nil
+}
@@ -0,0 +1,413 @@
+package sfgo
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/actgardner/gogen-avro/v7/compiler"
+	"github.com/actgardner/gogen-avro/v7/schema"
+	"github.com/actgardner/gogen-avro/v7/vm"
+)
+
+// DefaultMode controls what happens to reader fields missing from the writer schema.
+type DefaultMode int
+
+// DefaultMode enumeration.
+const (
+	// DefaultsApply applies the reader schema's declared defaults; decoding fails if a missing field has none.
+	DefaultsApply DefaultMode = iota
+	// DefaultsSkip leaves missing fields zeroed and clears them in the returned PresenceMask.
+	DefaultsSkip
+)
+
+// DecodeOptions configures DeserializeContainerWithOptions.
+type DecodeOptions struct {
+	Defaults DefaultMode
+	// NormalizeImages lowercases the registry host of decoded images (see NormalizeImageRegistry).
+	NormalizeImages bool
+	// OnWarning, if set, is called with data quality warnings about each decoded container,
+	// which is returned nonetheless.
+	OnWarning func(Warning)
+	// ConstantTime makes the data quality checks behind OnWarning use constant-time comparisons,
+	// checking every field, so their timing does not depend on the field values; without OnWarning
+	// it has no effect. Decoding itself still takes time depending on the record, e.g., on string
+	// lengths. NormalizeImages and LenientTypes, which branch on the decoded values, are rejected.
+	ConstantTime bool
+	// LenientTypes maps container types that are out of range or unknown to the reader schema to
+	// CT_CUSTOM instead of failing, reporting a WarnUnknownType warning, and records the decoded
+	// enum index (see RawType).
+	LenientTypes bool
+	// ErrorOnDefault makes decoding fail with an ErrFieldDefaulted error naming the first field
+	// missing from the writer schema, instead of applying its default (or leaving it zeroed).
+	ErrorOnDefault bool
+}
+
+// WarningCode classifies decode warnings.
+type WarningCode int
+
+// WarningCode enumeration.
+const (
+	// WarnMissingField reports a field absent from the writer schema.
+	WarnMissingField WarningCode = iota
+	// WarnEmptyField reports an empty id, image or image id.
+	WarnEmptyField
+	// WarnNAField reports an id, image or image id set to NA.
+	WarnNAField
+	// WarnUnknownType reports a container type outside the ContainerType enumeration.
+	WarnUnknownType
+)
+
+func (c WarningCode) String() string {
+	switch c {
+	case WarnMissingField:
+		return "MissingField"
+	case WarnEmptyField:
+		return "EmptyField"
+	case WarnNAField:
+		return "NAField"
+	case WarnUnknownType:
+		return "UnknownType"
+	}
+	return fmt.Sprintf("WarningCode(%d)", c)
+}
+
+// Warning is a data quality issue found while decoding a container.
+type Warning struct {
+	Code    WarningCode
+	Field   int // field index (see the ContainerField constants)
+	Message string
+}
+
+// PresenceMask records which reader fields were present in the writer schema, by field index.
+type PresenceMask uint64
+
+// Has checks whether the field with index i was present.
+func (m PresenceMask) Has(i int) bool {
+	return m&(1<<uint(i)) != 0
+}
+
+// allFieldsPresent is the presence mask of a container whose fields were all written.
+const allFieldsPresent = PresenceMask(1<<ContainerNumFields - 1)
+
+// resolvedProgram is a compiled resolving program along with the presence mask it produces.
+type resolvedProgram struct {
+	prog *vm.Program
+	mask PresenceMask
+	// types maps the writer's ContainerType symbol indices to reader values (-1 for symbols
+	// unknown to the reader); nil if both schemas declare the same symbols.
+	types   []ContainerType
+	symbols []string
+}
+
+type resolvedProgramKey struct {
+	schema string
+	mode   DefaultMode
+}
+
+// resolvedProgs caches resolving programs by writer schema and default mode.
+var resolvedProgs sync.Map
+
+// getResolvedContainerProgram compiles a program reading containers written with the writer schema,
+// reusing the non-resolving program if the writer schema matches the container schema.
+func getResolvedContainerProgram(writer string, mode DefaultMode) (*resolvedProgram, error) {
+	key := resolvedProgramKey{writer, mode}
+	if p, ok := resolvedProgs.Load(key); ok {
+		return p.(*resolvedProgram), nil
+	}
+	rec, err := parseContainerWriterSchema(writer)
+	if err != nil {
+		return nil, newCompileError(err)
+	}
+	mask := containerPresenceMask(rec)
+	reader := NewContainer().Schema()
+	if mode == DefaultsSkip && mask != allFieldsPresent {
+		if reader, err = containerSchemaWithZeroDefaults(mask); err != nil {
+			return nil, newCompileError(err)
+		}
+	}
+	var prog *vm.Program
+	if isContainerSchema(writer) {
+		// The schemas have the same canonical form, so no resolution is needed.
+		prog, err = getContainerProgram()
+	} else if prog, err = compiler.CompileSchemaBytes([]byte(writer), []byte(reader)); err != nil {
+		err = newCompileError(err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	p := &resolvedProgram{prog: prog, mask: mask}
+	p.types, p.symbols = containerTypeMap(rec)
+	resolvedProgs.Store(key, p)
+	return p, nil
+}
+
+// parseContainerWriterSchema parses a container writer schema.
+func parseContainerWriterSchema(writer string) (*schema.RecordDefinition, error) {
+	t, err := compiler.ParseSchema([]byte(writer))
+	if err != nil {
+		return nil, err
+	}
+	ref, ok := t.(*schema.Reference)
+	if !ok {
+		return nil, fmt.Errorf("writer schema is not a named record")
+	}
+	rec, ok := ref.Def.(*schema.RecordDefinition)
+	if !ok {
+		return nil, fmt.Errorf("writer schema is not a record")
+	}
+	return rec, nil
+}
+
+// containerPresenceMask computes which container fields are present in the writer schema.
+func containerPresenceMask(rec *schema.RecordDefinition) PresenceMask {
+	var mask PresenceMask
+	for i, name := range ContainerFieldNames {
+		if rec.FieldByName(name) != nil {
+			mask |= 1 << uint(i)
+		}
+	}
+	return mask
+}
+
+// containerTypeMap maps the ContainerType symbol indices of the writer schema to reader values
+// by symbol name. It returns nil if the writer declares the reader's symbols in the same order.
+func containerTypeMap(rec *schema.RecordDefinition) ([]ContainerType, []string) {
+	f := rec.FieldByName(ContainerFieldNames[ContainerFieldType])
+	if f == nil {
+		return nil, nil
+	}
+	ref, ok := f.Type().(*schema.Reference)
+	if !ok {
+		return nil, nil
+	}
+	enum, ok := ref.Def.(*schema.EnumDefinition)
+	if !ok {
+		return nil, nil
+	}
+	symbols := enum.Symbols()
+	types := make([]ContainerType, len(symbols))
+	identity := true
+	for i, sym := range symbols {
+		t, err := NewContainerTypeValue(sym)
+		if err != nil {
+			t = -1
+		}
+		types[i] = t
+		identity = identity && t == ContainerType(i)
+	}
+	if identity && len(symbols) == int(ContainerTypeCT_BPM)+1 {
+		return nil, nil
+	}
+	return types, symbols
+}
+
+// remapType translates a container type decoded with the writer's symbol index to the reader's.
+func (p *resolvedProgram) remapType(t *Container) error {
+	if p.types == nil {
+		return nil
+	}
+	i := int(t.Type)
+	if i < 0 || i >= len(p.types) {
+		return &Error{Kind: ErrDecode, Err: fmt.Errorf("container type index %d out of range of the writer schema", i)}
+	}
+	if p.types[i] < 0 {
+		return &Error{Kind: ErrDecode, Err: fmt.Errorf("container type '%s' unknown to the reader schema", p.symbols[i])}
+	}
+	t.Type = p.types[i]
+	return nil
+}
+
+// containerSchemaWithZeroDefaults returns the container schema with zero-value defaults declared
+// for the fields absent from mask, so that a resolving program can be compiled for them.
+func containerSchemaWithZeroDefaults(mask PresenceMask) (string, error) {
+	var s map[string]interface{}
+	if err := json.Unmarshal([]byte(NewContainer().Schema()), &s); err != nil {
+		return "", err
+	}
+	fields, _ := s["fields"].([]interface{})
+	for i, f := range fields {
+		if mask.Has(i) {
+			continue
+		}
+		field := f.(map[string]interface{})
+		switch i {
+		case ContainerFieldType:
+			field["default"] = ContainerTypeCT_DOCKER.String()
+		case ContainerFieldPrivileged:
+			field["default"] = false
+		case ContainerFieldPodID:
+			field["default"] = nil
+		default:
+			field["default"] = ""
+		}
+	}
+	b, err := json.Marshal(s)
+	return string(b), err
+}
+
+// containerDecodeTarget intercepts default assignments while decoding a container.
+type containerDecodeTarget struct {
+	*Container
+	opts      DecodeOptions
+	defaulted []int
+}
+
+func (t *containerDecodeTarget) SetDefault(i int) {
+	t.defaulted = append(t.defaulted, i)
+	if t.opts.ErrorOnDefault || t.opts.Defaults == DefaultsSkip {
+		return
+	}
+	t.Container.SetDefault(i)
+}
+
+// DeserializeContainerWithOptions decodes a container written with the writer schema, returning
+// the mask of fields present in that schema. Under LenientTypes, the decoded enum index is
+// recorded as the container's RawType.
+//
+// Avro encodes enums as the index of the symbol in the writer's symbol list, but the resolving
+// programs of gogen-avro copy that index verbatim. Records written with a schema whose
+// ContainerType symbols differ in order or number from the current ones, as in archives from
+// older producers, would thus decode to wrong types. Such writer schemas are detected and the
+// decoded index is mapped to the reader's symbol by name; symbols unknown to the reader fail
+// with an ErrDecode error. The same applies to DeserializeContainerWithSource.
+func DeserializeContainerWithOptions(r io.Reader, writer string, opts DecodeOptions) (*AnnotatedContainer, PresenceMask, error) {
+	if opts.ConstantTime && opts.NormalizeImages {
+		return nil, 0, &Error{Kind: ErrUnsupportedOp, Err: fmt.Errorf("image normalization is not constant-time")}
+	}
+	if opts.ConstantTime && opts.LenientTypes {
+		return nil, 0, &Error{Kind: ErrUnsupportedOp, Err: fmt.Errorf("lenient type mapping is not constant-time")}
+	}
+	mode := opts.Defaults
+	if opts.ErrorOnDefault {
+		// Declare zero defaults, so that missing fields without default reach SetDefault
+		// instead of failing the compilation.
+		mode = DefaultsSkip
+	}
+	p, err := getResolvedContainerProgram(writer, mode)
+	if err != nil {
+		return nil, 0, err
+	}
+	t := &containerDecodeTarget{Container: NewContainer(), opts: opts}
+	if err := evalEntity(r, p.prog, t); err != nil {
+		return nil, 0, err
+	}
+	if opts.ErrorOnDefault && len(t.defaulted) > 0 {
+		return nil, 0, &Error{Kind: ErrFieldDefaulted, Err: fmt.Errorf("field '%s' missing from the writer schema", ContainerFieldNames[t.defaulted[0]])}
+	}
+	a := NewAnnotatedContainer(t.Container)
+	if opts.LenientTypes {
+		raw := int(t.Container.Type)
+		a.meta.rawType = &raw
+		if err := p.remapType(t.Container); err != nil || t.Container.Type < ContainerTypeCT_DOCKER || t.Container.Type > ContainerTypeCT_BPM {
+			t.Container.Type = ContainerTypeCT_CUSTOM
+			if opts.OnWarning != nil {
+				opts.OnWarning(Warning{Code: WarnUnknownType, Field: ContainerFieldType, Message: fmt.Sprintf("unknown container type index %d mapped to CT_CUSTOM", raw)})
+			}
+		}
+	} else if err := p.remapType(t.Container); err != nil {
+		return nil, 0, err
+	}
+	if opts.NormalizeImages {
+		t.Container.Image = NormalizeImageRegistry(t.Container.Image)
+	}
+	if opts.OnWarning != nil {
+		if opts.ConstantTime {
+			t.Container.reportWarningsConstantTime(p.mask, opts.OnWarning)
+		} else {
+			t.Container.reportWarnings(p.mask, opts.OnWarning)
+		}
+	}
+	return a, p.mask, nil
+}
+
+// DeserializeContainerWithSource decodes a container written with the writer schema, e.g., one
+// resolved from a schema registry, and records that schema as the container's SourceSchema.
+// The recorded schema is in-memory metadata and does not affect serialization.
+func DeserializeContainerWithSource(r io.Reader, writer string) (*AnnotatedContainer, error) {
+	t, err := DeserializeContainerFromSchemaCached(r, writer)
+	if err != nil {
+		return nil, err
+	}
+	a := NewAnnotatedContainer(t)
+	a.meta.sourceSchema = writer
+	return a, nil
+}
+
+// DeserializeContainerFromSchemaCached decodes a container written with the writer schema like the
+// generated DeserializeContainerFromSchema, but reuses the resolving program compiled for the
+// writer schema, maps container types by symbol name (see DeserializeContainerWithOptions), and
+// returns errors wrapping the package's sentinel errors.
+func DeserializeContainerFromSchemaCached(r io.Reader, writer string) (*Container, error) {
+	p, err := getResolvedContainerProgram(writer, DefaultsApply)
+	if err != nil {
+		return nil, err
+	}
+	t := NewContainer()
+	if err := evalContainer(r, p.prog, t); err != nil {
+		return nil, err
+	}
+	if err := p.remapType(t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// reportWarnings reports the data quality warnings about a container decoded with the presence mask.
+func (r *Container) reportWarnings(mask PresenceMask, warn func(Warning)) {
+	for i, name := range ContainerFieldNames {
+		if !mask.Has(i) {
+			warn(Warning{Code: WarnMissingField, Field: i, Message: fmt.Sprintf("field '%s' missing from the writer schema", name)})
+		}
+	}
+	for _, i := range []int{ContainerFieldID, ContainerFieldImage, ContainerFieldImageID} {
+		if !mask.Has(i) {
+			continue
+		}
+		switch r.fieldString(i) {
+		case "":
+			warn(Warning{Code: WarnEmptyField, Field: i, Message: fmt.Sprintf("field '%s' is empty", ContainerFieldNames[i])})
+		case naValue:
+			warn(Warning{Code: WarnNAField, Field: i, Message: fmt.Sprintf("field '%s' is NA", ContainerFieldNames[i])})
+		}
+	}
+	if r.Type < ContainerTypeCT_DOCKER || r.Type > ContainerTypeCT_BPM {
+		warn(Warning{Code: WarnUnknownType, Field: ContainerFieldType, Message: fmt.Sprintf("unknown container type %d", r.Type)})
+	}
+}
+
+// reportWarningsConstantTime is reportWarnings with checks taking time independent of the field
+// values. Comparisons against NA are constant-time for strings of equal length only; other lengths
+// are rejected after comparing lengths, which leaks nothing the record size does not.
+func (r *Container) reportWarningsConstantTime(mask PresenceMask, warn func(Warning)) {
+	var codes [ContainerNumFields]int
+	for _, i := range []int{ContainerFieldID, ContainerFieldImage, ContainerFieldImageID} {
+		v := r.fieldString(i)
+		present := int(mask>>uint(i)) & 1
+		empty := subtle.ConstantTimeEq(int32(len(v)), 0)
+		na := subtle.ConstantTimeCompare([]byte(v), []byte(naValue))
+		// 0: none, 1+WarnEmptyField, 1+WarnNAField
+		codes[i] = subtle.ConstantTimeSelect(present, subtle.ConstantTimeSelect(empty, 1+int(WarnEmptyField), subtle.ConstantTimeSelect(na, 1+int(WarnNAField), 0)), 0)
+	}
+	t := int32(r.Type)
+	known := subtle.ConstantTimeLessOrEq(int(ContainerTypeCT_DOCKER), int(t)) & subtle.ConstantTimeLessOrEq(int(t), int(ContainerTypeCT_BPM))
+	codes[ContainerFieldType] = subtle.ConstantTimeSelect(known, 0, 1+int(WarnUnknownType))
+
+	for i, name := range ContainerFieldNames {
+		if !mask.Has(i) {
+			warn(Warning{Code: WarnMissingField, Field: i, Message: fmt.Sprintf("field '%s' missing from the writer schema", name)})
+		}
+	}
+	for i, code := range codes {
+		switch WarningCode(code - 1) {
+		case WarnEmptyField:
+			warn(Warning{Code: WarnEmptyField, Field: i, Message: fmt.Sprintf("field '%s' is empty", ContainerFieldNames[i])})
+		case WarnNAField:
+			warn(Warning{Code: WarnNAField, Field: i, Message: fmt.Sprintf("field '%s' is NA", ContainerFieldNames[i])})
+		case WarnUnknownType:
+			warn(Warning{Code: WarnUnknownType, Field: i, Message: fmt.Sprintf("unknown container type %d", r.Type)})
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package sfgo
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func decodeTestContainer() *Container {
+	c := NewContainer()
+	c.Id = "2f3b"
+	c.Name = "web"
+	c.Image = "nginx:1.21"
+	c.Type = ContainerTypeCT_CRIO
+	c.PodId = &PodIdUnion{UnionType: PodIdUnionTypeEnumString, String: "pod"}
+	return c
+}
+
+func TestDeserializeContainerBytesPadding(t *testing.T) {
+	c := decodeTestContainer()
+	rec, err := c.AppendBinary(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, padding := range []int{0, 1, 4096} {
+		b := append(append([]byte{}, rec...), bytes.Repeat([]byte{0xff}, padding)...)
+		d, n, err := DeserializeContainerBytes(b)
+		if err != nil {
+			t.Fatalf("padding %d: %v", padding, err)
+		}
+		if n != len(rec) {
+			t.Errorf("padding %d: consumed %d bytes, want %d", padding, n, len(rec))
+		}
+		if !d.Equal(c) {
+			t.Errorf("padding %d: decoded %+v, want %+v", padding, d, c)
+		}
+	}
+}
+
+func TestSafeDeserializeContainerCrafted(t *testing.T) {
+	strs := []byte{0x04, 'i', 'd', 0x00, 0x00, 0x00} // id "id", empty name, image and imageid
+	tests := []struct {
+		name string
+		r    io.Reader
+	}{
+		{"nil reader", nil},
+		{"empty", bytes.NewReader(nil)},
+		{"truncated", bytes.NewReader(strs[:2])},
+		{"negative string length", bytes.NewReader([]byte{0x01})},
+		{"huge string length", bytes.NewReader([]byte{0xfe, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f, 'a'})},
+		{"out-of-range enum", bytes.NewReader(append(strs, 0x28, 0x00, 0x00))},
+		{"negative enum", bytes.NewReader(append(strs, 0x01, 0x00, 0x00))},
+		{"bad union branch", bytes.NewReader(append(strs, 0x00, 0x00, 0x04))},
+		{"negative union branch", bytes.NewReader(append(strs, 0x00, 0x00, 0x01))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := SafeDeserializeContainer(tt.r)
+			if c != nil {
+				t.Errorf("decoded %+v, want nil", c)
+			}
+			if !errors.Is(err, ErrDecode) {
+				t.Errorf("error = %v, want ErrDecode", err)
+			}
+		})
+	}
+}
+
+func TestDeserializeContainerCachedErrors(t *testing.T) {
+	c := decodeTestContainer()
+	b, err := c.AppendBinary(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoders := map[string]func(io.Reader) (*Container, error){
+		"DeserializeContainerCached": DeserializeContainerCached,
+		"DeserializeContainerFromSchemaCached": func(r io.Reader) (*Container, error) {
+			return DeserializeContainerFromSchemaCached(r, c.Schema())
+		},
+	}
+	for name, decode := range decoders {
+		d, err := decode(bytes.NewReader(b))
+		if err != nil || !d.Equal(c) {
+			t.Errorf("%s = %+v, %v, want %+v", name, d, err, c)
+		}
+		if _, err := decode(bytes.NewReader(b[:len(b)-1])); !errors.Is(err, ErrTruncated) {
+			t.Errorf("%s on truncated input: error = %v, want ErrTruncated", name, err)
+		}
+		if _, err := decode(bytes.NewReader(nil)); err != io.EOF {
+			t.Errorf("%s on empty input: error = %v, want io.EOF", name, err)
+		}
+	}
+	if _, err := DeserializeContainerFromSchemaCached(bytes.NewReader(b), "{"); !errors.Is(err, ErrSchemaCompile) {
+		t.Errorf("DeserializeContainerFromSchemaCached with a bad schema: error = %v, want ErrSchemaCompile", err)
+	}
+}
@@ -0,0 +1,202 @@
+package sfgo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ContainerFieldChange describes a changed container field.
+type ContainerFieldChange struct {
+	Field    int // field index (see the ContainerField constants)
+	Old, New string
+}
+
+// ContainerChange describes a container whose attributes changed.
+type ContainerChange struct {
+	Old, New *Container
+	Fields   []ContainerFieldChange
+}
+
+// ContainerDiff describes the differences between two sets of containers.
+type ContainerDiff struct {
+	Added    []*Container
+	Removed  []*Container
+	Modified []ContainerChange
+}
+
+// DiffContainers compares two sets of containers, matching them by id (the first container with a
+// given id counts). Added and modified containers are listed in the order of new, removed ones in
+// the order of old; field changes are listed in schema order.
+func DiffContainers(old, new []*Container) ContainerDiff {
+	var d ContainerDiff
+	byID := make(map[string]*Container, len(old))
+	for _, c := range old {
+		if _, ok := byID[c.Id]; !ok {
+			byID[c.Id] = c
+		}
+	}
+	seen := make(map[string]bool, len(new))
+	for _, c := range new {
+		if seen[c.Id] {
+			continue
+		}
+		seen[c.Id] = true
+		o, ok := byID[c.Id]
+		if !ok {
+			d.Added = append(d.Added, c)
+			continue
+		}
+		if ch := o.changeTo(c); len(ch.Fields) > 0 {
+			d.Modified = append(d.Modified, ch)
+		}
+	}
+	for _, c := range old {
+		if !seen[c.Id] {
+			seen[c.Id] = true
+			d.Removed = append(d.Removed, c)
+		}
+	}
+	return d
+}
+
+// changeTo returns the field changes from r to other.
+func (r *Container) changeTo(other *Container) ContainerChange {
+	ch := ContainerChange{Old: r, New: other}
+	for i := 0; i < ContainerNumFields; i++ {
+		if !r.fieldEqual(other, i) {
+			ch.Fields = append(ch.Fields, ContainerFieldChange{Field: i, Old: r.reportValue(i), New: other.reportValue(i)})
+		}
+	}
+	return ch
+}
+
+// reportValue renders the field with index i for human consumption.
+func (r *Container) reportValue(i int) string {
+	switch i {
+	case ContainerFieldType:
+		return r.Type.RuntimeName()
+	case ContainerFieldPodID:
+		if r.PodId == nil {
+			return "none"
+		}
+	}
+	return fmt.Sprintf("%q", r.fieldString(i))
+}
+
+// ContainerChangeReport returns a plaintext report of the differences between two sets of
+// containers (see DiffContainers), starting with a summary line such as
+// "3 added, 1 removed, 2 modified" followed by one entry per container.
+func ContainerChangeReport(old, new []*Container) string {
+	d := DiffContainers(old, new)
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d added, %d removed, %d modified\n", len(d.Added), len(d.Removed), len(d.Modified))
+	for _, c := range d.Added {
+		fmt.Fprintf(&b, "+ %s\n", c.reportSummary())
+	}
+	for _, c := range d.Removed {
+		fmt.Fprintf(&b, "- %s\n", c.reportSummary())
+	}
+	for _, ch := range d.Modified {
+		fmt.Fprintf(&b, "~ %s\n", ch.New.reportSummary())
+		for _, f := range ch.Fields {
+			fmt.Fprintf(&b, "    %s: %s -> %s\n", ContainerFieldNames[f.Field], f.Old, f.New)
+		}
+	}
+	return b.String()
+}
+
+// reportSummary renders the container's identity for reports.
+func (r *Container) reportSummary() string {
+	return fmt.Sprintf("%s (%s, %s, %s)", r.Id, r.Name, r.Image, r.Type.RuntimeName())
+}
+
+// ContainerUpserts computes the minimal write set turning current into desired, matching
+// containers by key (the first container with a given key counts). Upserts holds the desired
+// containers that are new or changed (see Equal), deletes the current containers whose key is no
+// longer desired. Both are sorted by key, so that equal inputs always yield the same write sets.
+func ContainerUpserts(current, desired []*Container, key func(*Container) string) (upserts, deletes []*Container) {
+	cur := make(map[string]*Container, len(current))
+	for _, c := range current {
+		if k := key(c); cur[k] == nil {
+			cur[k] = c
+		}
+	}
+	want := make(map[string]*Container, len(desired))
+	var keys []string
+	for _, c := range desired {
+		k := key(c)
+		if want[k] != nil {
+			continue
+		}
+		want[k] = c
+		if o := cur[k]; o == nil || !o.Equal(c) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		upserts = append(upserts, want[k])
+	}
+	keys = keys[:0]
+	for k := range cur {
+		if want[k] == nil {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		deletes = append(deletes, cur[k])
+	}
+	return upserts, deletes
+}
+
+// TimelineEntry is a step of a container timeline: an observation that differs from the previous
+// one of the same key.
+type TimelineEntry struct {
+	Index     int   // position of the observation in the input
+	Timestamp int64 // timestamp of the observation, or 0 if the timeline is ordered by position
+	Container *Container
+	Changes   []ContainerFieldChange // changes from the previous step, nil for the first one
+}
+
+// BuildContainerTimeline builds per-key timelines from observations given in chronological order.
+// Each timeline starts with the first observation of its key, followed by every observation that
+// changes a field (see Equal); unchanged observations are skipped.
+func BuildContainerTimeline(observations []*Container, key func(*Container) string) map[string][]TimelineEntry {
+	return buildContainerTimeline(observations, key, nil)
+}
+
+// BuildContainerTimelineBy is BuildContainerTimeline for observations in arbitrary order, which
+// are ordered by the timestamps returned by ts; observations with equal timestamps keep their input
+// order.
+func BuildContainerTimelineBy(observations []*Container, key func(*Container) string, ts func(*Container) int64) map[string][]TimelineEntry {
+	return buildContainerTimeline(observations, key, ts)
+}
+
+func buildContainerTimeline(observations []*Container, key func(*Container) string, ts func(*Container) int64) map[string][]TimelineEntry {
+	order := make([]int, len(observations))
+	stamps := make([]int64, len(observations))
+	for i, c := range observations {
+		order[i] = i
+		if ts != nil {
+			stamps[i] = ts(c)
+		}
+	}
+	sort.SliceStable(order, func(a, b int) bool { return stamps[order[a]] < stamps[order[b]] })
+	timelines := make(map[string][]TimelineEntry)
+	for _, i := range order {
+		c := observations[i]
+		k := key(c)
+		tl := timelines[k]
+		e := TimelineEntry{Index: i, Timestamp: stamps[i], Container: c}
+		if len(tl) > 0 {
+			e.Changes = tl[len(tl)-1].Container.changeTo(c).Fields
+			if len(e.Changes) == 0 {
+				continue
+			}
+		}
+		timelines[k] = append(tl, e)
+	}
+	return timelines
+}
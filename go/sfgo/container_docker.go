@@ -0,0 +1,51 @@
+package sfgo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// dockerInspect holds the subset of `docker inspect` output mapped to containers.
+type dockerInspect struct {
+	ID     string `json:"Id"`
+	Name   string `json:"Name"`
+	Image  string `json:"Image"`
+	Config struct {
+		Image string `json:"Image"`
+	} `json:"Config"`
+	HostConfig struct {
+		Privileged bool `json:"Privileged"`
+	} `json:"HostConfig"`
+}
+
+// ContainerFromDockerInspect builds a Docker container from the JSON output of `docker inspect`,
+// either a single object or the one-element array the command emits. The name is stripped of
+// Docker's leading '/', and the image id is taken from the top-level Image attribute.
+func ContainerFromDockerInspect(b []byte) (*Container, error) {
+	var d dockerInspect
+	if t := bytes.TrimSpace(b); len(t) > 0 && t[0] == '[' {
+		var ds []dockerInspect
+		if err := json.Unmarshal(t, &ds); err != nil {
+			return nil, err
+		}
+		if len(ds) != 1 {
+			return nil, fmt.Errorf("expected a single inspected container, got %d", len(ds))
+		}
+		d = ds[0]
+	} else if err := json.Unmarshal(t, &d); err != nil {
+		return nil, err
+	}
+	if d.ID == "" {
+		return nil, fmt.Errorf("inspect output lacks a container id")
+	}
+	return &Container{
+		Id:         d.ID,
+		Name:       strings.TrimPrefix(d.Name, "/"),
+		Image:      d.Config.Image,
+		Imageid:    d.Image,
+		Type:       ContainerTypeCT_DOCKER,
+		Privileged: d.HostConfig.Privileged,
+	}, nil
+}
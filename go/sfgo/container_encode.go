@@ -0,0 +1,61 @@
+package sfgo
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// SerializeVerified serializes the container into a scratch buffer, decodes it back and writes
+// it to w only if the decoded record equals r. Otherwise, an ErrRoundTrip error is returned and
+// nothing is written.
+func (r *Container) SerializeVerified(w io.Writer) error {
+	var buf bytes.Buffer
+	if err := r.Serialize(&buf); err != nil {
+		return err
+	}
+	t, n, err := DeserializeContainerBytes(buf.Bytes())
+	if err != nil {
+		return &Error{Kind: ErrRoundTrip, Err: err}
+	}
+	if n != buf.Len() {
+		return &Error{Kind: ErrRoundTrip, Err: fmt.Errorf("decoded %d of %d bytes", n, buf.Len())}
+	}
+	if !t.Equal(r) {
+		return &Error{Kind: ErrRoundTrip, Err: fmt.Errorf("decoded record differs from original")}
+	}
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+// AppendBinary appends the Avro binary encoding of the container to dst, byte-for-byte as written
+// by Serialize, and returns the extended slice. It grows dst at most once. On error, dst is returned
+// unextended.
+func (r *Container) AppendBinary(dst []byte) ([]byte, error) {
+	n := len(dst)
+	if size := r.EncodedSize(); cap(dst)-n < size {
+		grown := make([]byte, n, n+size)
+		copy(grown, dst)
+		dst = grown
+	}
+	w := &appendWriter{b: dst}
+	if err := r.Serialize(w); err != nil {
+		return dst[:n], err
+	}
+	return w.b, nil
+}
+
+// appendWriter is an io.Writer appending to a byte slice.
+type appendWriter struct {
+	b []byte
+}
+
+func (w *appendWriter) Write(p []byte) (int, error) {
+	w.b = append(w.b, p...)
+	return len(p), nil
+}
+
+func (w *appendWriter) WriteString(s string) (int, error) {
+	w.b = append(w.b, s...)
+	return len(s), nil
+}
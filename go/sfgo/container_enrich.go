@@ -0,0 +1,68 @@
+package sfgo
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/sysflow-telemetry/sf-apis/go/logger"
+)
+
+// ContainerEnricher derives or normalizes container fields right after decoding.
+type ContainerEnricher func(*Container) error
+
+// EnricherErrorMode controls how enrichment errors are handled.
+type EnricherErrorMode int
+
+// EnricherErrorMode enumeration.
+const (
+	// EnricherErrorsFatal makes the decoder fail with the enrichment error.
+	EnricherErrorsFatal EnricherErrorMode = iota
+	// EnricherErrorsLog logs enrichment errors as warnings and continues with the next enricher.
+	EnricherErrorsLog
+)
+
+var (
+	enrichersMu  sync.RWMutex
+	enrichers    []ContainerEnricher
+	enricherMode EnricherErrorMode
+)
+
+// RegisterContainerEnricher registers an enricher that the streaming and batch decoders
+// (ContainerOCFReader, ContainerOCFIndex, ContainerMergeReader, SFStreamReader.NextContainer,
+// SyncContainerReader, DeserializeContainersChan, DeserializeFirstNContainers,
+// DeserializeContainerBatch, ContainersSeq and ExtractContainers) run on every decoded container
+// before returning it. The single-record Deserialize functions do not run enrichers.
+//
+// Enrichers run in registration order on the decoding goroutine. Since decoders may run
+// concurrently, enrichers must be safe for concurrent use; each invocation receives a distinct
+// container. Enrichers should be registered during initialization, before decoding starts.
+func RegisterContainerEnricher(fn ContainerEnricher) {
+	enrichersMu.Lock()
+	defer enrichersMu.Unlock()
+	enrichers = append(enrichers[:len(enrichers):len(enrichers)], fn)
+}
+
+// SetEnricherErrorMode sets how enrichment errors are handled (EnricherErrorsFatal by default).
+func SetEnricherErrorMode(mode EnricherErrorMode) {
+	enrichersMu.Lock()
+	defer enrichersMu.Unlock()
+	enricherMode = mode
+}
+
+// enrichContainer runs the registered enrichers on c.
+func enrichContainer(c *Container) error {
+	enrichersMu.RLock()
+	fns, mode := enrichers, enricherMode
+	enrichersMu.RUnlock()
+	for i, fn := range fns {
+		if err := fn(c); err != nil {
+			if mode == EnricherErrorsFatal {
+				return fmt.Errorf("enricher %d failed on container '%s': %w", i, c.Id, err)
+			}
+			if logger.Warn != nil {
+				logger.Warn.Printf("enricher %d failed on container '%s': %v", i, c.Id, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package sfgo
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+)
+
+// Enrichment is the result of a per-image enrichment, such as vulnerability data or labels.
+type Enrichment interface{}
+
+// defaultEnrichmentCacheSize is the capacity used for invalid cache sizes.
+const defaultEnrichmentCacheSize = 1024
+
+// ContainerEnrichmentCache caches per-image enrichments keyed by NormalizedImageID, evicting the
+// least recently used entry when full. It is safe for concurrent use; concurrent requests for the
+// same image wait for a single computation.
+type ContainerEnrichmentCache struct {
+	mu       sync.Mutex
+	size     int
+	lru      *list.List // of *enrichmentEntry, most recently used first
+	entries  map[string]*list.Element
+	inflight map[string]*enrichmentCall
+}
+
+type enrichmentEntry struct {
+	key string
+	val Enrichment
+}
+
+// enrichmentCall is an enrichment being computed.
+type enrichmentCall struct {
+	done chan struct{}
+	val  Enrichment
+	err  error
+}
+
+// NewContainerEnrichmentCache creates a cache holding up to size enrichments (1024 if not positive).
+func NewContainerEnrichmentCache(size int) *ContainerEnrichmentCache {
+	if size <= 0 {
+		size = defaultEnrichmentCacheSize
+	}
+	return &ContainerEnrichmentCache{
+		size:     size,
+		lru:      list.New(),
+		entries:  make(map[string]*list.Element),
+		inflight: make(map[string]*enrichmentCall),
+	}
+}
+
+// GetOrCompute returns the cached enrichment of the container's image, computing it with fn if
+// needed. Errors are returned to all callers waiting for the computation but are not cached; if fn
+// panics, the waiting callers get an error and the panic propagates to the computing caller.
+// Containers without image id (empty or NA) are never cached, since they do not identify an image.
+func (ec *ContainerEnrichmentCache) GetOrCompute(r *Container, fn func(*Container) (Enrichment, error)) (Enrichment, error) {
+	if isAbsent(r.Imageid) {
+		return fn(r)
+	}
+	key := r.NormalizedImageID()
+	ec.mu.Lock()
+	if e, ok := ec.entries[key]; ok {
+		ec.lru.MoveToFront(e)
+		ec.mu.Unlock()
+		return e.Value.(*enrichmentEntry).val, nil
+	}
+	if call, ok := ec.inflight[key]; ok {
+		ec.mu.Unlock()
+		<-call.done
+		return call.val, call.err
+	}
+	call := &enrichmentCall{done: make(chan struct{})}
+	ec.inflight[key] = call
+	ec.mu.Unlock()
+
+	ec.compute(key, call, r, fn)
+	return call.val, call.err
+}
+
+// compute runs fn for an in-flight call and completes it, even if fn panics: the panic is
+// reported to the waiting callers as an error and then propagated.
+func (ec *ContainerEnrichmentCache) compute(key string, call *enrichmentCall, r *Container, fn func(*Container) (Enrichment, error)) {
+	defer ec.complete(key, call)
+	defer func() {
+		if p := recover(); p != nil {
+			call.val, call.err = nil, fmt.Errorf("enrichment of image '%s' panicked: %v", key, p)
+			panic(p)
+		}
+	}()
+	call.val, call.err = fn(r)
+}
+
+// complete caches a successful call's result and releases the callers waiting for it.
+func (ec *ContainerEnrichmentCache) complete(key string, call *enrichmentCall) {
+	ec.mu.Lock()
+	delete(ec.inflight, key)
+	if call.err == nil {
+		ec.entries[key] = ec.lru.PushFront(&enrichmentEntry{key: key, val: call.val})
+		if ec.lru.Len() > ec.size {
+			last := ec.lru.Back()
+			ec.lru.Remove(last)
+			delete(ec.entries, last.Value.(*enrichmentEntry).key)
+		}
+	}
+	ec.mu.Unlock()
+	close(call.done)
+}
+
+// Len returns the number of cached enrichments.
+func (ec *ContainerEnrichmentCache) Len() int {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+	return ec.lru.Len()
+}
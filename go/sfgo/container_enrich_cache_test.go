@@ -0,0 +1,36 @@
+package sfgo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEnrichmentCachePanic(t *testing.T) {
+	ec := NewContainerEnrichmentCache(4)
+	c := decodeTestContainer()
+	c.Imageid = "sha256:98ab"
+	func() {
+		defer func() {
+			if p := recover(); p != "boom" {
+				t.Errorf("recovered %v, want the enrichment panic", p)
+			}
+		}()
+		ec.GetOrCompute(c, func(*Container) (Enrichment, error) { panic("boom") })
+	}()
+	done := make(chan Enrichment)
+	go func() {
+		v, _ := ec.GetOrCompute(c, func(*Container) (Enrichment, error) { return "ok", nil })
+		done <- v
+	}()
+	select {
+	case v := <-done:
+		if v != "ok" {
+			t.Errorf("GetOrCompute = %v, want ok", v)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("GetOrCompute blocked after a panicking computation")
+	}
+	if ec.Len() != 1 {
+		t.Errorf("Len = %d, want 1", ec.Len())
+	}
+}
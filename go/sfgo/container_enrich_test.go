@@ -0,0 +1,42 @@
+package sfgo
+
+import (
+	"bytes"
+	"testing"
+)
+
+// withTestEnricher registers fn as the only enricher for the duration of the test.
+func withTestEnricher(t *testing.T, fn ContainerEnricher) {
+	enrichersMu.Lock()
+	saved := enrichers
+	enrichers = []ContainerEnricher{fn}
+	enrichersMu.Unlock()
+	t.Cleanup(func() {
+		enrichersMu.Lock()
+		enrichers = saved
+		enrichersMu.Unlock()
+	})
+}
+
+// enrichName is a test enricher marking the containers it ran on.
+func enrichName(c *Container) error {
+	c.Name = "enriched"
+	return nil
+}
+
+func TestDeserializeContainerBatchEnrich(t *testing.T) {
+	withTestEnricher(t, enrichName)
+	var buf bytes.Buffer
+	if err := SerializeContainerBatch(&buf, []*Container{decodeTestContainer(), decodeTestContainer()}); err != nil {
+		t.Fatal(err)
+	}
+	cs, err := DeserializeContainerBatch(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, c := range cs {
+		if c.Name != "enriched" {
+			t.Errorf("container %d not enriched: %+v", i, c)
+		}
+	}
+}
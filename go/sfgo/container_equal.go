@@ -0,0 +1,50 @@
+package sfgo
+
+// Equal checks whether two containers have the same field values.
+func (r *Container) Equal(other *Container) bool {
+	return r.EqualExcept(other)
+}
+
+// EqualExcept checks whether two containers have the same field values, ignoring the fields
+// with the given indices (see the ContainerField constants). It panics on out-of-range indices.
+func (r *Container) EqualExcept(other *Container, ignore ...int) bool {
+	var skip [ContainerNumFields]bool
+	for _, i := range ignore {
+		if i < 0 || i >= ContainerNumFields {
+			panic("Unknown field index")
+		}
+		skip[i] = true
+	}
+	if r == nil || other == nil {
+		return r == other
+	}
+	for i := 0; i < ContainerNumFields; i++ {
+		if !skip[i] && !r.fieldEqual(other, i) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Container) fieldEqual(other *Container, i int) bool {
+	switch i {
+	case ContainerFieldID:
+		return r.Id == other.Id
+	case ContainerFieldName:
+		return r.Name == other.Name
+	case ContainerFieldImage:
+		return r.Image == other.Image
+	case ContainerFieldImageID:
+		return r.Imageid == other.Imageid
+	case ContainerFieldType:
+		return r.Type == other.Type
+	case ContainerFieldPrivileged:
+		return r.Privileged == other.Privileged
+	case ContainerFieldPodID:
+		if r.PodId == nil || other.PodId == nil {
+			return r.PodId == other.PodId
+		}
+		return r.PodId.UnionType == other.PodId.UnionType && r.PodId.String == other.PodId.String
+	}
+	panic("Unknown field index")
+}
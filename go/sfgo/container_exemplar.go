@@ -0,0 +1,49 @@
+package sfgo
+
+import "unicode/utf8"
+
+// Exemplar label names.
+const (
+	ExemplarContainerID      = "container_id"
+	ExemplarContainerImage   = "container_image"
+	ExemplarContainerRuntime = "container_runtime"
+)
+
+// maxExemplarLabelRunes is the OpenMetrics limit on the combined length of exemplar label names and values.
+const maxExemplarLabelRunes = 128
+
+// ExemplarLabels returns a small label set identifying the container, for use in OpenMetrics
+// exemplars: the short container id, the image repository (without tag or digest) and the
+// runtime. High-cardinality attributes such as the image id are never included, absent values
+// are omitted, and the image is truncated so that the set stays within the 128-character limit
+// OpenMetrics places on exemplar labels.
+func (r *Container) ExemplarLabels() map[string]string {
+	m := make(map[string]string, 3)
+	size := 0
+	add := func(k, v string) {
+		if isAbsent(v) {
+			return
+		}
+		m[k] = v
+		size += utf8.RuneCountInString(k) + utf8.RuneCountInString(v)
+	}
+	id := r.Id
+	if len(id) > defaultShortIDLen {
+		id = id[:defaultShortIDLen]
+	}
+	add(ExemplarContainerID, id)
+	add(ExemplarContainerRuntime, r.Type.FalcoType())
+	image, ok := r.RepositoryOf()
+	if !ok {
+		return m
+	}
+	room := maxExemplarLabelRunes - size - utf8.RuneCountInString(ExemplarContainerImage)
+	if room <= 0 {
+		return m
+	}
+	if utf8.RuneCountInString(image) > room {
+		image = string([]rune(image)[:room])
+	}
+	add(ExemplarContainerImage, image)
+	return m
+}
@@ -0,0 +1,90 @@
+package sfgo
+
+import "encoding/json"
+
+// ExportOptions controls how containers are rendered by the JSON, ECS and Falco exporters.
+// The in-memory record is never modified.
+type ExportOptions struct {
+	// CoalesceNA treats string attributes equal to "NA" or "" as absent: they are rendered
+	// as null in JSON and omitted from the ECS and Falco field maps.
+	CoalesceNA bool
+}
+
+// isAbsent checks whether s denotes an absent string attribute.
+func isAbsent(s string) bool {
+	return s == "" || s == naValue
+}
+
+type containerJSON struct {
+	Id         *string       `json:"id"`
+	Name       *string       `json:"name"`
+	Image      *string       `json:"image"`
+	Imageid    *string       `json:"imageid"`
+	Type       ContainerType `json:"type"`
+	Privileged bool          `json:"privileged"`
+	PodId      *PodIdUnion   `json:"podId"`
+}
+
+// MarshalJSONWithOptions renders the container as JSON, with the same layout as json.Marshal.
+func (r *Container) MarshalJSONWithOptions(opts ExportOptions) ([]byte, error) {
+	if !opts.CoalesceNA {
+		return json.Marshal(r)
+	}
+	str := func(s string) *string {
+		if isAbsent(s) {
+			return nil
+		}
+		return &s
+	}
+	c := &containerJSON{
+		Id:         str(r.Id),
+		Name:       str(r.Name),
+		Image:      str(r.Image),
+		Imageid:    str(r.Imageid),
+		Type:       r.Type,
+		Privileged: r.Privileged,
+		PodId:      r.PodId,
+	}
+	if r.PodId != nil && isAbsent(r.PodId.String) {
+		c.PodId = nil
+	}
+	return json.Marshal(c)
+}
+
+// ECS container field names.
+const (
+	ECSContainerID         = "container.id"
+	ECSContainerName       = "container.name"
+	ECSContainerImageName  = "container.image.name"
+	ECSContainerImageTag   = "container.image.tag"
+	ECSContainerImageHash  = "container.image.hash.all"
+	ECSContainerRuntime    = "container.runtime"
+	ECSContainerPrivileged = "container.security_context.privileged"
+)
+
+// ECSFields returns the container attributes keyed by Elastic Common Schema field names.
+// The image name and tag are taken from the parsed image reference when possible.
+func (r *Container) ECSFields(opts ExportOptions) map[string]interface{} {
+	m := make(map[string]interface{})
+	set := func(k, v string) {
+		if !opts.CoalesceNA || !isAbsent(v) {
+			m[k] = v
+		}
+	}
+	set(ECSContainerID, r.Id)
+	set(ECSContainerName, r.Name)
+	if ref, err := ParseImageRef(r.Image); err == nil && !isAbsent(r.Image) {
+		set(ECSContainerImageName, ref.Repository())
+		if ref.Tag != "" {
+			m[ECSContainerImageTag] = []string{ref.Tag}
+		}
+	} else {
+		set(ECSContainerImageName, r.Image)
+	}
+	if !opts.CoalesceNA || !isAbsent(r.Imageid) {
+		m[ECSContainerImageHash] = []string{r.Imageid}
+	}
+	set(ECSContainerRuntime, r.Type.FalcoType())
+	m[ECSContainerPrivileged] = r.Privileged
+	return m
+}
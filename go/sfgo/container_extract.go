@@ -0,0 +1,101 @@
+package sfgo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/actgardner/gogen-avro/v7/compiler"
+	"github.com/actgardner/gogen-avro/v7/schema"
+	"github.com/actgardner/gogen-avro/v7/vm"
+)
+
+// recContainerIndex is the index of the Container branch in the SysFlow record union.
+const recContainerIndex = int64(RecUnionTypeEnumContainer)
+
+var (
+	recSkipProgs     []*vm.Program
+	recSkipProgsErr  error
+	recSkipProgsOnce sync.Once
+)
+
+// getRecSkipPrograms returns, for each branch of the SysFlow record union, a program that
+// reads past a value of that branch without materializing it.
+func getRecSkipPrograms() ([]*vm.Program, error) {
+	recSkipProgsOnce.Do(func() {
+		recSkipProgs, recSkipProgsErr = compileRecSkipPrograms()
+		if recSkipProgsErr != nil {
+			recSkipProgsErr = newCompileError(recSkipProgsErr)
+		}
+	})
+	return recSkipProgs, recSkipProgsErr
+}
+
+func compileRecSkipPrograms() ([]*vm.Program, error) {
+	t, err := compiler.ParseSchema([]byte(NewSysFlow().Schema()))
+	if err != nil {
+		return nil, err
+	}
+	ref, ok := t.(*schema.Reference)
+	if !ok {
+		return nil, errors.New("SysFlow schema is not a named record")
+	}
+	rec, ok := ref.Def.(*schema.RecordDefinition)
+	if !ok || len(rec.Fields()) != 1 {
+		return nil, errors.New("SysFlow schema is not a single-field record")
+	}
+	union, ok := rec.Fields()[0].Type().(*schema.UnionField)
+	if !ok {
+		return nil, errors.New("SysFlow record field is not a union")
+	}
+	var progs []*vm.Program
+	for _, branch := range union.AvroTypes() {
+		p, err := compiler.Compile(branch, nil)
+		if err != nil {
+			return nil, err
+		}
+		progs = append(progs, p)
+	}
+	return progs, nil
+}
+
+// ExtractContainers reads a stream of SysFlow records and returns the containers contained in it.
+// Records of other types are skipped without being materialized.
+func ExtractContainers(r io.Reader) ([]*Container, error) {
+	skip, err := getRecSkipPrograms()
+	if err != nil {
+		return nil, err
+	}
+	deser, err := getContainerProgram()
+	if err != nil {
+		return nil, err
+	}
+	cr := &countingReader{r: r}
+	var cs []*Container
+	for {
+		idx, err := readLong(cr)
+		if err == io.EOF {
+			return cs, nil
+		} else if err != nil {
+			return cs, newDecodeError(err)
+		}
+		switch {
+		case idx == recContainerIndex:
+			t := NewContainer()
+			if err := evalContainer(cr, deser, t); err != nil {
+				return cs, newDecodeError(err)
+			}
+			if err := enrichContainer(t); err != nil {
+				return cs, err
+			}
+			cs = append(cs, t)
+		case idx >= 0 && idx < int64(len(skip)):
+			if err := vm.Eval(cr, skip[idx], nil); err != nil {
+				return cs, newDecodeError(err)
+			}
+		default:
+			return cs, &Error{Kind: ErrDecode, Err: fmt.Errorf("invalid SysFlow record union index %d", idx)}
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package sfgo
+
+import "strconv"
+
+// Falco container field names.
+const (
+	FalcoContainerID         = "container.id"
+	FalcoContainerName       = "container.name"
+	FalcoContainerImage      = "container.image"
+	FalcoContainerImageID    = "container.image.id"
+	FalcoContainerPrivileged = "container.privileged"
+	FalcoContainerType       = "container.type"
+)
+
+// naValue is the placeholder used by SysFlow producers for unknown string attributes.
+const naValue = "NA"
+
+// falcoTypes maps container types to Falco's container runtime strings.
+var falcoTypes = map[ContainerType]string{
+	ContainerTypeCT_DOCKER:      "docker",
+	ContainerTypeCT_LXC:         "lxc",
+	ContainerTypeCT_LIBVIRT_LXC: "libvirt-lxc",
+	ContainerTypeCT_MESOS:       "mesos",
+	ContainerTypeCT_RKT:         "rkt",
+	ContainerTypeCT_CUSTOM:      "custom",
+	ContainerTypeCT_CRI:         "cri",
+	ContainerTypeCT_CONTAINERD:  "containerd",
+	ContainerTypeCT_CRIO:        "cri-o",
+	ContainerTypeCT_BPM:         "bpm",
+}
+
+// FalcoType returns Falco's runtime string for a container type, or an empty string if unknown.
+func (e ContainerType) FalcoType() string {
+	return falcoTypes[e]
+}
+
+// FalcoFields returns the container attributes keyed by Falco's container.* field names.
+// NA values are rendered as empty strings, which is how Falco denotes absent attributes.
+func (r *Container) FalcoFields() map[string]string {
+	return r.FalcoFieldsWithOptions(ExportOptions{})
+}
+
+// FalcoFieldsWithOptions is like FalcoFields, but omits absent attributes if opts.CoalesceNA is set.
+func (r *Container) FalcoFieldsWithOptions(opts ExportOptions) map[string]string {
+	m := map[string]string{
+		FalcoContainerID:         falcoValue(r.Id),
+		FalcoContainerName:       falcoValue(r.Name),
+		FalcoContainerImage:      falcoValue(r.Image),
+		FalcoContainerImageID:    falcoValue(r.Imageid),
+		FalcoContainerPrivileged: strconv.FormatBool(r.Privileged),
+		FalcoContainerType:       r.Type.FalcoType(),
+	}
+	if opts.CoalesceNA {
+		for k, v := range m {
+			if v == "" {
+				delete(m, k)
+			}
+		}
+	}
+	return m
+}
+
+func falcoValue(s string) string {
+	if s == naValue {
+		return ""
+	}
+	return s
+}
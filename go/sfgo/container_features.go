@@ -0,0 +1,41 @@
+package sfgo
+
+import "hash/fnv"
+
+// Layout of Container.FeatureVector. Changing it requires bumping FeatureVectorVersion.
+const (
+	// FeatureVectorVersion identifies the feature vector layout, for checking model compatibility.
+	FeatureVectorVersion = 1
+	// FeatureVectorLen is the length of a feature vector.
+	FeatureVectorLen = featureImageOffset + featureImageBuckets
+
+	featureTypeOffset       = 0
+	featurePrivilegedOffset = featureTypeOffset + int(ContainerTypeCT_BPM) + 1
+	featureImageOffset      = featurePrivilegedOffset + 1
+	featureImageBuckets     = 64 - featureImageOffset
+)
+
+// FeatureVector encodes the container as a vector of FeatureVectorLen (64) values in {0, 1}:
+//
+//	[0, 10)   one-hot runtime type, indexed by ContainerType (all 0 for invalid types)
+//	[10]      privileged flag
+//	[11, 64)  one-hot bucket of the image repository (see RepositoryOf), hashed with FNV-1a into
+//	          53 buckets (all 0 if the image is NA, empty or unparseable)
+//
+// The image repository rather than the image is hashed, so that containers of different tags
+// of an image share a bucket.
+func (r *Container) FeatureVector() []float64 {
+	v := make([]float64, FeatureVectorLen)
+	if r.Type >= ContainerTypeCT_DOCKER && r.Type <= ContainerTypeCT_BPM {
+		v[featureTypeOffset+int(r.Type)] = 1
+	}
+	if r.Privileged {
+		v[featurePrivilegedOffset] = 1
+	}
+	if repo, ok := r.RepositoryOf(); ok {
+		h := fnv.New64a()
+		h.Write([]byte(repo))
+		v[featureImageOffset+int(h.Sum64()%uint64(featureImageBuckets))] = 1
+	}
+	return v
+}
@@ -0,0 +1,69 @@
+package sfgo
+
+import "strconv"
+
+// Container field indices, in schema order (as used by Get, SetDefault and NullField).
+const (
+	ContainerFieldID = iota
+	ContainerFieldName
+	ContainerFieldImage
+	ContainerFieldImageID
+	ContainerFieldType
+	ContainerFieldPrivileged
+	ContainerFieldPodID
+	ContainerNumFields
+)
+
+// ContainerFieldNames lists the Avro field names of a container, indexed by field index.
+var ContainerFieldNames = [ContainerNumFields]string{"id", "name", "image", "imageid", "type", "privileged", "podId"}
+
+// FlatMap returns the container attributes as strings keyed by Avro field name (see ContainerFieldNames).
+// The type is rendered as its enum symbol, privileged as "true" or "false", and a null podId as "".
+func (r *Container) FlatMap() map[string]string {
+	m := make(map[string]string, ContainerNumFields)
+	for i, name := range ContainerFieldNames {
+		m[name] = r.fieldString(i)
+	}
+	return m
+}
+
+// KV is a key-value pair.
+type KV struct {
+	Key   string
+	Value string
+}
+
+// FlatMapOrdered returns the entries of FlatMap in schema field order. To iterate over FlatMap or
+// GenericRecord deterministically, range over ContainerFieldNames, which lists their keys in
+// schema order.
+func (r *Container) FlatMapOrdered() []KV {
+	kvs := make([]KV, ContainerNumFields)
+	for i, name := range ContainerFieldNames {
+		kvs[i] = KV{Key: name, Value: r.fieldString(i)}
+	}
+	return kvs
+}
+
+// fieldString renders the field with index i as a string.
+func (r *Container) fieldString(i int) string {
+	switch i {
+	case ContainerFieldID:
+		return r.Id
+	case ContainerFieldName:
+		return r.Name
+	case ContainerFieldImage:
+		return r.Image
+	case ContainerFieldImageID:
+		return r.Imageid
+	case ContainerFieldType:
+		return r.Type.String()
+	case ContainerFieldPrivileged:
+		return strconv.FormatBool(r.Privileged)
+	case ContainerFieldPodID:
+		if r.PodId == nil {
+			return ""
+		}
+		return r.PodId.String
+	}
+	panic("Unknown field index")
+}
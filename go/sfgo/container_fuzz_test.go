@@ -0,0 +1,71 @@
+//go:build go1.18
+
+package sfgo
+
+import (
+	"bytes"
+	"testing"
+)
+
+// Native fuzz targets for the container decoders, run with, e.g.,
+//
+//	go test -fuzz FuzzDeserializeContainer ./sfgo
+//
+// Besides the seeds added here, the corpus in testdata/fuzz holds truncated records and records
+// with negative or oversized string lengths. A decoder panic fails the target, as does a decoded
+// container that does not round-trip.
+
+// addContainerFuzzSeeds seeds f with valid container encodings.
+func addContainerFuzzSeeds(f *testing.F) {
+	c := NewContainer()
+	f.Add(mustAppendBinary(f, c))
+	c.Id, c.Name, c.Image, c.Imageid = "2f3b", "web", "docker.io/library/nginx:1.21", "sha256:98ab"
+	c.Type, c.Privileged = ContainerTypeCT_CRIO, true
+	c.PodId = &PodIdUnion{UnionType: PodIdUnionTypeEnumString, String: "pod-1"}
+	f.Add(mustAppendBinary(f, c))
+}
+
+func mustAppendBinary(f *testing.F, c *Container) []byte {
+	b, err := c.AppendBinary(nil)
+	if err != nil {
+		f.Fatal(err)
+	}
+	return b
+}
+
+func FuzzDeserializeContainer(f *testing.F) {
+	addContainerFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		c, err := DeserializeContainer(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		checkFuzzRoundTrip(t, c)
+	})
+}
+
+func FuzzDeserializeContainerBytes(f *testing.F) {
+	addContainerFuzzSeeds(f)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		c, n, err := DeserializeContainerBytes(data)
+		if n < 0 || n > len(data) {
+			t.Fatalf("consumed %d of %d bytes", n, len(data))
+		}
+		if err != nil {
+			return
+		}
+		checkFuzzRoundTrip(t, c)
+	})
+}
+
+// checkFuzzRoundTrip checks that the container re-serializes to bytes decoding to an equal container.
+func checkFuzzRoundTrip(t *testing.T, c *Container) {
+	b, err := c.AppendBinary(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, n, err := DeserializeContainerBytes(b)
+	if err != nil || n != len(b) || !d.Equal(c) {
+		t.Fatalf("container %+v does not round-trip: %v", c, err)
+	}
+}
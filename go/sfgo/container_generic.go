@@ -0,0 +1,22 @@
+package sfgo
+
+// GenericRecord returns the container as a generic Avro record keyed by Avro field name (see
+// ContainerFieldNames), following the conventions of schema-driven libraries such as goavro:
+// the type is its enum symbol, a null podId is nil, and a non-null podId is wrapped in a
+// single-entry map keyed by its branch type name ("string"). Unlike FlatMap, values keep their
+// Avro types.
+func (r *Container) GenericRecord() map[string]interface{} {
+	var podID interface{}
+	if r.PodId != nil && r.PodId.UnionType == PodIdUnionTypeEnumString {
+		podID = map[string]interface{}{"string": r.PodId.String}
+	}
+	return map[string]interface{}{
+		ContainerFieldNames[ContainerFieldID]:         r.Id,
+		ContainerFieldNames[ContainerFieldName]:       r.Name,
+		ContainerFieldNames[ContainerFieldImage]:      r.Image,
+		ContainerFieldNames[ContainerFieldImageID]:    r.Imageid,
+		ContainerFieldNames[ContainerFieldType]:       r.Type.String(),
+		ContainerFieldNames[ContainerFieldPrivileged]: r.Privileged,
+		ContainerFieldNames[ContainerFieldPodID]:      podID,
+	}
+}
@@ -0,0 +1,58 @@
+package sfgo
+
+// GraphNode is a serializer-agnostic graph node with typed edges to other nodes.
+type GraphNode struct {
+	ID     string
+	Type   string
+	Labels map[string]string
+	Props  map[string]interface{}
+	Edges  []GraphEdge
+}
+
+// GraphEdge is a typed edge to the node with id Target.
+type GraphEdge struct {
+	Type   string
+	Target string
+}
+
+// Graph node and edge types produced by Container.GraphNode.
+const (
+	GraphNodeContainer = "container"
+	GraphEdgeImage     = "image"
+	GraphEdgeRepo      = "repo"
+)
+
+// GraphNode returns the container as a graph node keyed by container id, with its attributes as
+// properties and edges to its image (by normalized image id) and image repository (see RepositoryOf).
+// Edges to absent targets are omitted. The node has no labels.
+func (r *Container) GraphNode() GraphNode {
+	n := GraphNode{
+		ID:     r.Id,
+		Type:   GraphNodeContainer,
+		Labels: map[string]string{},
+		Props: map[string]interface{}{
+			ContainerFieldNames[ContainerFieldName]:       r.Name,
+			ContainerFieldNames[ContainerFieldImage]:      r.Image,
+			ContainerFieldNames[ContainerFieldType]:       r.Type.String(),
+			ContainerFieldNames[ContainerFieldPrivileged]: r.Privileged,
+		},
+	}
+	if r.PodId != nil && r.PodId.UnionType == PodIdUnionTypeEnumString {
+		n.Props[ContainerFieldNames[ContainerFieldPodID]] = r.PodId.String
+	}
+	if !isAbsent(r.Imageid) {
+		n.Edges = append(n.Edges, GraphEdge{Type: GraphEdgeImage, Target: r.NormalizedImageID()})
+	}
+	if repo, ok := r.RepositoryOf(); ok {
+		n.Edges = append(n.Edges, GraphEdge{Type: GraphEdgeRepo, Target: repo})
+	}
+	return n
+}
+
+// GraphNode returns the container as a graph node (see Container.GraphNode) labeled with its
+// in-memory labels.
+func (a *AnnotatedContainer) GraphNode() GraphNode {
+	n := a.Container.GraphNode()
+	n.Labels = a.Labels()
+	return n
+}
@@ -0,0 +1,29 @@
+package sfgo
+
+// ContainerKeyFunc derives a grouping key from a container.
+type ContainerKeyFunc func(*Container) string
+
+// Common container key functions.
+var (
+	KeyByImage ContainerKeyFunc = func(c *Container) string { return c.Image }
+	KeyByType  ContainerKeyFunc = func(c *Container) string { return c.Type.String() }
+)
+
+// GroupContainers groups containers by key, preserving their relative order within each group.
+func GroupContainers(cs []*Container, key func(*Container) string) map[string][]*Container {
+	groups := make(map[string][]*Container)
+	for _, c := range cs {
+		k := key(c)
+		groups[k] = append(groups[k], c)
+	}
+	return groups
+}
+
+// CountContainers counts containers by key.
+func CountContainers(cs []*Container, key func(*Container) string) map[string]int {
+	counts := make(map[string]int)
+	for _, c := range cs {
+		counts[key(c)]++
+	}
+	return counts
+}
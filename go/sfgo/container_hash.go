@@ -0,0 +1,29 @@
+package sfgo
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// ContentHash returns the SHA-256 digest of the container's binary Avro encoding, which is
+// canonical for a given schema: logically identical containers hash identically regardless of
+// how they were built. A podId union holding the null branch is hashed like a nil podId.
+func (r *Container) ContentHash() [sha256.Size]byte {
+	c := *r
+	if c.PodId != nil && c.PodId.UnionType != PodIdUnionTypeEnumString {
+		c.PodId = nil
+	}
+	var buf bytes.Buffer
+	buf.Grow(c.EncodedSize())
+	if err := c.Serialize(&buf); err != nil {
+		panic(err)
+	}
+	return sha256.Sum256(buf.Bytes())
+}
+
+// ContentHashHex returns ContentHash as a lowercase hex string.
+func (r *Container) ContentHashHex() string {
+	h := r.ContentHash()
+	return hex.EncodeToString(h[:])
+}
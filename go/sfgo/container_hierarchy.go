@@ -0,0 +1,58 @@
+package sfgo
+
+import "strings"
+
+// InferContainerHierarchy infers parent/child relationships among nested containers from their
+// ids and names, returning a map from child id to parent id (empty if none are found). Parents
+// must be among cs; the heuristics, applied in order, are:
+//
+//   - LXC and libvirt-LXC containers whose name is a nesting path such as "outer/inner", as listed
+//     by lxc-ls --nesting, are children of the container named by the path without its last element.
+//   - Mesos nested containers, whose ids are the parent's id followed by a dot and the child's own
+//     id, are children of the container with the longest such prefix id.
+//   - Containers whose id is a cgroup-style path, i.e., the parent's id, a slash and the child's
+//     own id, are children of the container with the longest such prefix id, whatever their type.
+func InferContainerHierarchy(cs []*Container) map[string]string {
+	ids := make(map[string]bool, len(cs))
+	names := make(map[string]string, len(cs))
+	for _, c := range cs {
+		ids[c.Id] = true
+		if _, ok := names[c.Name]; !ok && c.Name != "" {
+			names[c.Name] = c.Id
+		}
+	}
+	parents := make(map[string]string)
+	for _, c := range cs {
+		if p, ok := inferParent(c, ids, names); ok && p != c.Id {
+			parents[c.Id] = p
+		}
+	}
+	return parents
+}
+
+// inferParent applies the nesting heuristics of InferContainerHierarchy to c.
+func inferParent(c *Container, ids map[string]bool, names map[string]string) (string, bool) {
+	if c.Type == ContainerTypeCT_LXC || c.Type == ContainerTypeCT_LIBVIRT_LXC {
+		if i := strings.LastIndexByte(c.Name, '/'); i > 0 {
+			if p, ok := names[c.Name[:i]]; ok {
+				return p, true
+			}
+		}
+	}
+	if c.Type == ContainerTypeCT_MESOS {
+		if p, ok := longestIDPrefix(c.Id, '.', ids); ok {
+			return p, true
+		}
+	}
+	return longestIDPrefix(c.Id, '/', ids)
+}
+
+// longestIDPrefix returns the longest proper prefix of id ending before sep that is a known id.
+func longestIDPrefix(id string, sep byte, ids map[string]bool) (string, bool) {
+	for i := strings.LastIndexByte(id, sep); i > 0; i = strings.LastIndexByte(id[:i], sep) {
+		if ids[id[:i]] {
+			return id[:i], true
+		}
+	}
+	return "", false
+}
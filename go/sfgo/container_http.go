@@ -0,0 +1,114 @@
+package sfgo
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Page sizes of ContainerHTTPHandler.
+const (
+	defaultHTTPPageSize = 100
+	maxHTTPPageSize     = 1000
+)
+
+// containerPage is a page of the container list served by ContainerHTTPHandler.
+type containerPage struct {
+	Total      int          `json:"total"`
+	Offset     int          `json:"offset"`
+	Limit      int          `json:"limit"`
+	Containers []*Container `json:"containers"`
+}
+
+// ContainerHTTPHandler serves the containers as a read-only JSON API:
+//
+//	GET /containers        a page of containers matching the query, as an object with the fields
+//	                       total (number of matches), offset, limit and containers
+//	GET /containers/{id}   the first container with the id
+//
+// The list accepts the query parameters image (substring of the image), type (container type,
+// parsed with ParseContainerTypeLenient), offset (default 0) and limit (default 100, at most 1000).
+// Container types are rendered as enum symbols.
+func ContainerHTTPHandler(cs []*Container) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/containers", func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		q := req.URL.Query()
+		image := q.Get("image")
+		var typ *ContainerType
+		if s := q.Get("type"); s != "" {
+			t, err := ParseContainerTypeLenient(s)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			typ = &t
+		}
+		offset, err := httpIntParam(q.Get("offset"), 0)
+		if err != nil {
+			http.Error(w, "invalid offset", http.StatusBadRequest)
+			return
+		}
+		limit, err := httpIntParam(q.Get("limit"), defaultHTTPPageSize)
+		if err != nil || limit == 0 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		if limit > maxHTTPPageSize {
+			limit = maxHTTPPageSize
+		}
+		page := containerPage{Offset: offset, Limit: limit, Containers: []*Container{}}
+		for _, c := range cs {
+			if (typ != nil && c.Type != *typ) || !strings.Contains(c.Image, image) {
+				continue
+			}
+			if page.Total >= offset && len(page.Containers) < limit {
+				page.Containers = append(page.Containers, c)
+			}
+			page.Total++
+		}
+		writeHTTPJSON(w, page)
+	})
+	mux.HandleFunc("/containers/", func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		id := strings.TrimPrefix(req.URL.Path, "/containers/")
+		for _, c := range cs {
+			if c.Id == id {
+				writeHTTPJSON(w, c)
+				return
+			}
+		}
+		http.NotFound(w, req)
+	})
+	return mux
+}
+
+// httpIntParam parses a non-negative integer query parameter, returning def if it is empty.
+func httpIntParam(s string, def int) (int, error) {
+	if s == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err == nil && n < 0 {
+		err = strconv.ErrRange
+	}
+	return n, err
+}
+
+// writeHTTPJSON writes v as a JSON response.
+func writeHTTPJSON(w http.ResponseWriter, v interface{}) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(append(b, '\n'))
+}
@@ -0,0 +1,33 @@
+package sfgo
+
+import "regexp"
+
+var (
+	hexIDRe  = regexp.MustCompile(`^(?:[0-9a-f]{12}|[0-9a-f]{64})$`)
+	uuidIDRe = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+	nameIDRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.:-]{0,254}$`)
+)
+
+// ValidID checks whether the container id is well-formed for the container's runtime type.
+// Docker and CRI runtimes (CRI, containerd, CRI-O) use lowercase hex ids, either full (64
+// characters) or truncated (12 characters); Mesos and rkt use lowercase UUIDs; the remaining
+// runtimes use names, which must start with an alphanumeric character and consist of at most
+// 255 alphanumerics, '_', '.', ':' or '-' characters. NA is never a valid id.
+func (r *Container) ValidID() bool {
+	switch r.Type {
+	case ContainerTypeCT_DOCKER, ContainerTypeCT_CRI, ContainerTypeCT_CONTAINERD, ContainerTypeCT_CRIO:
+		return hexIDRe.MatchString(r.Id)
+	case ContainerTypeCT_MESOS, ContainerTypeCT_RKT:
+		return uuidIDRe.MatchString(r.Id)
+	case ContainerTypeCT_LXC, ContainerTypeCT_LIBVIRT_LXC, ContainerTypeCT_CUSTOM, ContainerTypeCT_BPM:
+		return r.Id != naValue && nameIDRe.MatchString(r.Id)
+	}
+	return false
+}
+
+// CorrelationKey returns a composite key "<id>/<normalized image id>" for joining processes with
+// their containers. Container ids alone are not unique across hosts (short ids in particular may
+// collide or be reused), so the image id is included to disambiguate.
+func (r *Container) CorrelationKey() string {
+	return r.Id + "/" + r.NormalizedImageID()
+}
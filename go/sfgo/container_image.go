@@ -0,0 +1,317 @@
+package sfgo
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	imageComponentRe = regexp.MustCompile(`^[A-Za-z0-9]+(?:(?:[._]|__|-+)[A-Za-z0-9]+)*$`)
+	imageHostRe      = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9.-]*[A-Za-z0-9])?(?::[0-9]+)?$`)
+	imageTagRe       = regexp.MustCompile(`^[\w][\w.-]{0,127}$`)
+	imageDigestRe    = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*(?:[-_+.][A-Za-z][A-Za-z0-9]*)*:[0-9A-Fa-f]{32,}$`)
+	imageVersionRe   = regexp.MustCompile(`^[vV]?([0-9]+(?:\.[0-9]+)*)(?:-(.+))?$`)
+)
+
+// ImageRef is a parsed container image reference of the form [registry/]path[:tag][@digest].
+type ImageRef struct {
+	Registry string
+	Path     string
+	Tag      string
+	Digest   string
+}
+
+// ParseImageRef parses a container image reference.
+func ParseImageRef(image string) (ImageRef, error) {
+	var ref ImageRef
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		ref.Digest = name[i+1:]
+		name = name[:i]
+		if !imageDigestRe.MatchString(ref.Digest) {
+			return ImageRef{}, fmt.Errorf("invalid digest in image reference '%s'", image)
+		}
+	}
+	if i := strings.LastIndex(name, ":"); i >= 0 && !strings.Contains(name[i+1:], "/") {
+		ref.Tag = name[i+1:]
+		name = name[:i]
+		if !imageTagRe.MatchString(ref.Tag) {
+			return ImageRef{}, fmt.Errorf("invalid tag in image reference '%s'", image)
+		}
+	}
+	if i := strings.Index(name, "/"); i >= 0 {
+		if host := name[:i]; strings.ContainsAny(host, ".:") || host == "localhost" {
+			if !imageHostRe.MatchString(host) {
+				return ImageRef{}, fmt.Errorf("invalid registry in image reference '%s'", image)
+			}
+			ref.Registry = host
+			name = name[i+1:]
+		}
+	}
+	if name == "" {
+		return ImageRef{}, fmt.Errorf("invalid image reference '%s'", image)
+	}
+	for _, c := range strings.Split(name, "/") {
+		if !imageComponentRe.MatchString(c) {
+			return ImageRef{}, fmt.Errorf("invalid path in image reference '%s'", image)
+		}
+	}
+	ref.Path = name
+	return ref, nil
+}
+
+// Repository returns the image repository, i.e., the reference without tag and digest.
+func (ref ImageRef) Repository() string {
+	if ref.Registry != "" {
+		return ref.Registry + "/" + ref.Path
+	}
+	return ref.Path
+}
+
+// NormalizeImageRegistry lowercases the registry host of an image reference, which is
+// case-insensitive, while preserving the case of the path, tag and digest. Images without
+// registry or that cannot be parsed are returned unchanged.
+func NormalizeImageRegistry(image string) string {
+	ref, err := ParseImageRef(image)
+	if err != nil || ref.Registry == "" {
+		return image
+	}
+	return strings.ToLower(ref.Registry) + image[len(ref.Registry):]
+}
+
+// RepositoryOf derives the repository of the container image, i.e., the image reference
+// without tag and digest; ok is false when Image is NA, empty, or unparseable.
+func (r *Container) RepositoryOf() (repo string, ok bool) {
+	if r.Image == "" || r.Image == naValue {
+		return "", false
+	}
+	ref, err := ParseImageRef(r.Image)
+	if err != nil {
+		return "", false
+	}
+	return ref.Repository(), true
+}
+
+// DeriveImagerepo records the repository of Image (see RepositoryOf) as the ImageRepoLabel when
+// the label is absent, NA or empty, and reports whether it did. The SysFlow schema has no
+// imagerepo attribute, so the label is the only place the repository is kept. The label is left
+// untouched when Image is unparseable.
+func (a *AnnotatedContainer) DeriveImagerepo() bool {
+	if _, ok := a.ImageRepo(); ok {
+		return false
+	}
+	repo, ok := a.RepositoryOf()
+	if !ok {
+		return false
+	}
+	a.SetLabel(ImageRepoLabel, repo)
+	return true
+}
+
+// ImageRepo returns the recorded image repository, i.e., the ImageRepoLabel (as set by
+// DeriveImagerepo); ok is false when it is absent, NA or empty.
+func (a *AnnotatedContainer) ImageRepo() (repo string, ok bool) {
+	repo, ok = a.Label(ImageRepoLabel)
+	if !ok || repo == "" || repo == naValue {
+		return "", false
+	}
+	return repo, true
+}
+
+// RepoConsistent checks whether the recorded image repository (see ImageRepo) matches the
+// repository of Image, returning an explanation if it does not. If no repository is recorded,
+// the check is skipped and reported as consistent. Registry hosts are compared
+// case-insensitively.
+func (a *AnnotatedContainer) RepoConsistent() (bool, string) {
+	recorded, ok := a.ImageRepo()
+	if !ok {
+		return true, "imagerepo not recorded, not checked"
+	}
+	repo, ok := a.RepositoryOf()
+	if !ok {
+		return false, fmt.Sprintf("imagerepo '%s' recorded for image '%s' without repository", recorded, a.Image)
+	}
+	if NormalizeImageRegistry(recorded) != NormalizeImageRegistry(repo) {
+		return false, fmt.Sprintf("imagerepo '%s' does not match repository '%s' of image '%s'", recorded, repo, a.Image)
+	}
+	return true, ""
+}
+
+// defaultRegistryHost is the registry implied by image references without registry.
+const defaultRegistryHost = "docker.io"
+
+// RegistryHost returns the lowercase registry host of the container image, including its port if
+// any (e.g., "registry.local:5000"), or "docker.io" for references without registry, such as
+// "nginx" or "app@sha256:<hex>", per Docker convention. It returns an empty string for NA, empty
+// and unparseable images and for bare digests ("sha256:<hex>"), which name no registry.
+func (r *Container) RegistryHost() string {
+	if isAbsent(r.Image) || imageDigestRe.MatchString(r.Image) {
+		return ""
+	}
+	ref, err := ParseImageRef(r.Image)
+	if err != nil {
+		return ""
+	}
+	if ref.Registry == "" {
+		return defaultRegistryHost
+	}
+	return strings.ToLower(ref.Registry)
+}
+
+// defaultImageTag is the tag implied by image references without tag and digest.
+const defaultImageTag = "latest"
+
+// ImageTag returns the tag of the container image, "latest" if the image has neither tag nor
+// digest, or an empty string if it is pinned by digest only, NA, empty or unparseable. Registry
+// ports (e.g., host:5000/repo) are not mistaken for tags.
+func (r *Container) ImageTag() string {
+	if r.Image == "" || r.Image == naValue {
+		return ""
+	}
+	ref, err := ParseImageRef(r.Image)
+	if err != nil {
+		return ""
+	}
+	if ref.Tag == "" && ref.Digest == "" {
+		return defaultImageTag
+	}
+	return ref.Tag
+}
+
+// ImageSortKey returns a key for ordering containers by image version, such that comparing keys
+// as strings sorts images as follows:
+//
+//   - by repository (registry host lowercased), so that versions of an image stay together;
+//   - within a repository, version tags first, then other tags (e.g., "latest", "stable") in
+//     lexical order, then digest-only references;
+//   - version tags, i.e., dot-separated numbers with optional "v" prefix and "-suffix", by
+//     comparing numbers numerically (1.9 < 1.10, leading zeros ignored) and then by length
+//     (1.2 < 1.2.0 < 1.2.1); a suffixed version precedes the plain one and suffixes compare
+//     lexically (1.2-alpine < 1.2-rc1 < 1.2), as semver pre-releases do.
+//
+// Images without tag and digest have the implied tag "latest". NA, empty and unparseable images
+// have an empty key and sort first. Keys are meant for comparison only; their format may change.
+func (r *Container) ImageSortKey() string {
+	if r.Image == "" || r.Image == naValue {
+		return ""
+	}
+	ref, err := ParseImageRef(r.Image)
+	if err != nil {
+		return ""
+	}
+	ref.Registry = strings.ToLower(ref.Registry)
+	key := ref.Repository() + "\x00"
+	switch {
+	case ref.Tag == "" && ref.Digest != "":
+		return key + "3" + ref.Digest
+	case ref.Tag == "":
+		return key + "2" + defaultImageTag
+	}
+	if v, ok := imageVersionKey(ref.Tag); ok {
+		return key + "1" + v
+	}
+	return key + "2" + ref.Tag
+}
+
+// imageVersionKey encodes a version tag as a string comparing in version order (see ImageSortKey).
+// Numbers are prefixed with their two-digit length, so that longer numbers compare greater; tags
+// with longer numbers are not considered versions.
+func imageVersionKey(tag string) (string, bool) {
+	m := imageVersionRe.FindStringSubmatch(tag)
+	if m == nil {
+		return "", false
+	}
+	var b strings.Builder
+	for i, n := range strings.Split(m[1], ".") {
+		if n = strings.TrimLeft(n, "0"); n == "" {
+			n = "0"
+		}
+		if len(n) > 99 {
+			return "", false
+		}
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		fmt.Fprintf(&b, "%02d%s", len(n), n)
+	}
+	// '!' ends the numbers, sorting before '.' so that shorter versions come first; '-' (suffixed)
+	// sorts before '~' (plain).
+	if m[2] != "" {
+		b.WriteString("!-" + m[2])
+	} else {
+		b.WriteString("!~")
+	}
+	return b.String(), true
+}
+
+// defaultShortIDLen is the length of short (git-style) image ids.
+const defaultShortIDLen = 12
+
+// NormalizedImageID returns the container image id in lowercase, without surrounding
+// whitespace and without a "sha256:" algorithm prefix.
+func (r *Container) NormalizedImageID() string {
+	id := strings.ToLower(strings.TrimSpace(r.Imageid))
+	return strings.TrimPrefix(id, "sha256:")
+}
+
+// ShortImageID returns the first n characters of the normalized image id, or the whole id
+// if it is shorter. If n is not positive, it defaults to 12.
+func (r *Container) ShortImageID(n int) string {
+	if n <= 0 {
+		n = defaultShortIDLen
+	}
+	id := r.NormalizedImageID()
+	if len(id) > n {
+		return id[:n]
+	}
+	return id
+}
+
+// ImageTokens splits the container image into lowercase tokens for full-text indexing, in order
+// of appearance and without duplicates: the registry with its host and port as separate tokens,
+// the path and each of its segments, the tag, and the digest with its algorithm and hex value.
+// Digest-only references, with or without repository (e.g., "sha256:<hex>"), yield the digest
+// tokens. NA, empty and unparseable images yield no tokens.
+func (r *Container) ImageTokens() []string {
+	if isAbsent(r.Image) {
+		return nil
+	}
+	image := strings.ToLower(r.Image)
+	var ref ImageRef
+	if imageDigestRe.MatchString(image) {
+		ref.Digest = image
+	} else if parsed, err := ParseImageRef(image); err == nil {
+		ref = parsed
+	} else {
+		return nil
+	}
+	var tokens []string
+	seen := make(map[string]bool)
+	add := func(ts ...string) {
+		for _, t := range ts {
+			if t != "" && !seen[t] {
+				seen[t] = true
+				tokens = append(tokens, t)
+			}
+		}
+	}
+	if ref.Registry != "" {
+		add(ref.Registry)
+		if i := strings.LastIndexByte(ref.Registry, ':'); i >= 0 {
+			add(ref.Registry[:i], ref.Registry[i+1:])
+		}
+	}
+	if ref.Path != "" {
+		add(ref.Path)
+		add(strings.Split(ref.Path, "/")...)
+	}
+	add(ref.Tag)
+	if ref.Digest != "" {
+		add(ref.Digest)
+		if i := strings.IndexByte(ref.Digest, ':'); i >= 0 {
+			add(ref.Digest[:i], ref.Digest[i+1:])
+		}
+	}
+	return tokens
+}
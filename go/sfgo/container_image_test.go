@@ -0,0 +1,30 @@
+package sfgo
+
+import "testing"
+
+func TestDeriveImagerepo(t *testing.T) {
+	for _, tc := range []struct {
+		image, recorded string
+		derived         bool
+		want            string
+	}{
+		{"registry.local:5000/team/app:1.2@sha256:0123456789abcdef0123456789abcdef", "", true, "registry.local:5000/team/app"},
+		{"nginx:latest", naValue, true, "nginx"},
+		{"nginx:latest", "mirror/nginx", false, "mirror/nginx"},
+		{"not a ref", "", false, ""},
+		{naValue, "", false, ""},
+	} {
+		a := NewAnnotatedContainer(NewContainer())
+		a.Image = tc.image
+		if tc.recorded != "" {
+			a.SetLabel(ImageRepoLabel, tc.recorded)
+		}
+		if got := a.DeriveImagerepo(); got != tc.derived {
+			t.Errorf("%q: DeriveImagerepo() = %v, want %v", tc.image, got, tc.derived)
+		}
+		repo, ok := a.ImageRepo()
+		if repo != tc.want || ok != (tc.want != "") {
+			t.Errorf("%q: ImageRepo() = %q, %v, want %q", tc.image, repo, ok, tc.want)
+		}
+	}
+}
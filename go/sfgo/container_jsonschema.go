@@ -0,0 +1,200 @@
+package sfgo
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	contJSONSchema     map[string]interface{}
+	contJSONSchemaText string
+	contJSONSchemaOnce sync.Once
+)
+
+// getContainerJSONSchema derives the JSON Schema of the container from its Avro schema.
+func getContainerJSONSchema() (map[string]interface{}, string) {
+	contJSONSchemaOnce.Do(func() {
+		var avsc interface{}
+		if err := json.Unmarshal([]byte(NewContainer().Schema()), &avsc); err != nil {
+			panic(err)
+		}
+		contJSONSchema = avroToJSONSchema(avsc)
+		contJSONSchema["$schema"] = "http://json-schema.org/draft-07/schema#"
+		b, err := json.Marshal(contJSONSchema)
+		if err != nil {
+			panic(err)
+		}
+		contJSONSchemaText = string(b)
+	})
+	return contJSONSchema, contJSONSchemaText
+}
+
+// ContainerJSONSchema returns a JSON Schema (draft-07) describing the JSON encoding of containers
+// produced by json.Marshal. It is derived from the Avro schema: records become closed objects with
+// all fields required, enums strings restricted to their symbols, and unions of null and a type
+// either null or an object wrapping the value under the type name, as in Avro's JSON encoding.
+func ContainerJSONSchema() string {
+	_, s := getContainerJSONSchema()
+	return s
+}
+
+// avroToJSONSchema translates the parsed JSON of an Avro schema to a JSON Schema.
+func avroToJSONSchema(t interface{}) map[string]interface{} {
+	switch t := t.(type) {
+	case string:
+		switch t {
+		case "null":
+			return map[string]interface{}{"type": "null"}
+		case "boolean":
+			return map[string]interface{}{"type": "boolean"}
+		case "int", "long":
+			return map[string]interface{}{"type": "integer"}
+		case "float", "double":
+			return map[string]interface{}{"type": "number"}
+		}
+		return map[string]interface{}{"type": "string"}
+	case []interface{}:
+		var alts []interface{}
+		for _, branch := range t {
+			if branch == "null" {
+				alts = append(alts, avroToJSONSchema(branch))
+				continue
+			}
+			name, _ := branch.(string)
+			alts = append(alts, map[string]interface{}{
+				"type":                 "object",
+				"properties":           map[string]interface{}{name: avroToJSONSchema(branch)},
+				"required":             []interface{}{name},
+				"additionalProperties": false,
+			})
+		}
+		return map[string]interface{}{"oneOf": alts}
+	case map[string]interface{}:
+		switch t["type"] {
+		case "record":
+			props := make(map[string]interface{})
+			var required []interface{}
+			fields, _ := t["fields"].([]interface{})
+			for _, f := range fields {
+				f := f.(map[string]interface{})
+				name := f["name"].(string)
+				props[name] = avroToJSONSchema(f["type"])
+				required = append(required, name)
+			}
+			return map[string]interface{}{
+				"title":                t["name"],
+				"type":                 "object",
+				"properties":           props,
+				"required":             required,
+				"additionalProperties": false,
+			}
+		case "enum":
+			return map[string]interface{}{"type": "string", "enum": t["symbols"]}
+		}
+		return avroToJSONSchema(t["type"])
+	}
+	return map[string]interface{}{}
+}
+
+// ValidateJSON marshals the container and validates the result against ContainerJSONSchema,
+// returning a *ContainerValidationError listing every violation by JSON pointer. Unlike Validate,
+// it checks the record as serialized, thus also catching values the JSON encoding cannot represent.
+func (r *Container) ValidateJSON() error {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return &ContainerValidationError{Problems: []string{err.Error()}}
+	}
+	var doc interface{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return &ContainerValidationError{Problems: []string{err.Error()}}
+	}
+	s, _ := getContainerJSONSchema()
+	if problems := validateJSONSchema(s, doc, ""); len(problems) > 0 {
+		return &ContainerValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// validateJSONSchema validates v against the subset of JSON Schema produced by avroToJSONSchema.
+func validateJSONSchema(s map[string]interface{}, v interface{}, path string) []string {
+	at := path
+	if at == "" {
+		at = "/"
+	}
+	if alts, ok := s["oneOf"].([]interface{}); ok {
+		matches := 0
+		for _, alt := range alts {
+			if len(validateJSONSchema(alt.(map[string]interface{}), v, path)) == 0 {
+				matches++
+			}
+		}
+		if matches != 1 {
+			return []string{fmt.Sprintf("%s: matches %d of %d union branches", at, matches, len(alts))}
+		}
+		return nil
+	}
+	if typ, ok := s["type"].(string); ok && !jsonTypeIs(v, typ) {
+		return []string{fmt.Sprintf("%s: expected %s", at, typ)}
+	}
+	var problems []string
+	if enum, ok := s["enum"].([]interface{}); ok {
+		found := false
+		for _, sym := range enum {
+			found = found || sym == v
+		}
+		if !found {
+			problems = append(problems, fmt.Sprintf("%s: %v is not an enum symbol", at, v))
+		}
+	}
+	obj, _ := v.(map[string]interface{})
+	props, _ := s["properties"].(map[string]interface{})
+	if req, ok := s["required"].([]interface{}); ok {
+		for _, name := range req {
+			if _, ok := obj[name.(string)]; !ok {
+				problems = append(problems, fmt.Sprintf("%s/%s: missing", path, name))
+			}
+		}
+	}
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		ps, ok := props[k].(map[string]interface{})
+		if !ok {
+			if s["additionalProperties"] == false {
+				problems = append(problems, fmt.Sprintf("%s/%s: unexpected property", path, k))
+			}
+			continue
+		}
+		problems = append(problems, validateJSONSchema(ps, obj[k], path+"/"+k)...)
+	}
+	return problems
+}
+
+// jsonTypeIs checks whether a decoded JSON value has the JSON Schema type typ.
+func jsonTypeIs(v interface{}, typ string) bool {
+	switch typ {
+	case "null":
+		return v == nil
+	case "boolean":
+		_, ok := v.(bool)
+		return ok
+	case "integer":
+		f, ok := v.(float64)
+		return ok && f == float64(int64(f))
+	case "number":
+		_, ok := v.(float64)
+		return ok
+	case "string":
+		_, ok := v.(string)
+		return ok
+	case "object":
+		_, ok := v.(map[string]interface{})
+		return ok
+	}
+	return true
+}
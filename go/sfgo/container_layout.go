@@ -0,0 +1,44 @@
+package sfgo
+
+import "io"
+
+// FieldLayout is the byte range encoding a field of a binary-encoded container, relative to the
+// start of the record.
+type FieldLayout struct {
+	Name   string // Avro field name (see ContainerFieldNames)
+	Offset int
+	Length int
+}
+
+// DeserializeContainerWithLayout decodes a container from r like DeserializeContainer and also
+// returns the byte range consumed by each field, in schema order. The ranges are contiguous and
+// cover the record: they are measured on the consumed bytes, so they are exact even for
+// non-canonical encodings (e.g., padded varints), and match FieldSizes and EncodedSize for records
+// written by Serialize. Layout tracking costs a second pass over the record, and is thus limited to
+// this variant.
+func DeserializeContainerWithLayout(r io.Reader) (*Container, []FieldLayout, error) {
+	t, raw, err := DeserializeContainerWithRaw(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	layout, err := containerLayout(raw)
+	if err != nil {
+		return nil, nil, newDecodeError(err)
+	}
+	return t, layout, nil
+}
+
+// containerLayout computes the field ranges of a binary-encoded container.
+func containerLayout(raw []byte) ([]FieldLayout, error) {
+	ends, err := scanContainerFields(raw)
+	if err != nil {
+		return nil, err
+	}
+	layout := make([]FieldLayout, ContainerNumFields)
+	off := 0
+	for i, name := range ContainerFieldNames {
+		layout[i] = FieldLayout{Name: name, Offset: off, Length: ends[i] - off}
+		off = ends[i]
+	}
+	return layout, nil
+}
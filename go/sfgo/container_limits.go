@@ -0,0 +1,132 @@
+package sfgo
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// DecodeLimits bounds the resources a single decoded container may use. Zero fields are unlimited.
+type DecodeLimits struct {
+	MaxTotalBytes  int64 // encoded size of the record
+	MaxStringBytes int64 // combined length of all its strings
+}
+
+// DefaultDecodeLimits are limits suitable for untrusted input; containers are normally well
+// below a kilobyte. Use DecodeLimits{} to disable all limits.
+var DefaultDecodeLimits = DecodeLimits{MaxTotalBytes: 1 << 20, MaxStringBytes: 512 << 10}
+
+// DeserializeContainerLimited decodes a container from r, rejecting it with an ErrLimitExceeded
+// error naming the violated limit as soon as it exceeds one. String lengths are checked before
+// any memory is allocated for them.
+func DeserializeContainerLimited(r io.Reader, limits DecodeLimits) (*Container, error) {
+	raw, err := readLimitedContainer(r, limits)
+	if err != nil {
+		return nil, err
+	}
+	c, _, err := DeserializeContainerBytes(raw)
+	if err != nil {
+		return nil, newDecodeError(err)
+	}
+	return c, nil
+}
+
+// limitedScan reads the encoding of a record field by field while enforcing limits.
+type limitedScan struct {
+	cr       *countingReader
+	limits   DecodeLimits
+	strBytes int64
+}
+
+// readLimitedContainer reads the raw encoding of the next container from r.
+// It returns io.EOF if r is at its end.
+func readLimitedContainer(r io.Reader, limits DecodeLimits) ([]byte, error) {
+	var raw bytes.Buffer
+	s := &limitedScan{cr: &countingReader{r: io.TeeReader(r, &raw)}, limits: limits}
+	// id
+	if err := s.skipString(); err == io.EOF {
+		return nil, io.EOF
+	} else if err != nil {
+		return nil, err
+	}
+	// name, image, imageid
+	for i := 0; i < 3; i++ {
+		if err := s.skipString(); err != nil {
+			return nil, err
+		}
+	}
+	// type
+	if err := s.skipLong(); err != nil {
+		return nil, err
+	}
+	// privileged
+	if _, err := s.cr.ReadByte(); err != nil {
+		return nil, s.wrap(err)
+	}
+	// podId
+	branch, err := readLong(s.cr)
+	if err != nil {
+		return nil, s.wrap(err)
+	}
+	if PodIdUnionTypeEnum(branch) == PodIdUnionTypeEnumString {
+		if err := s.skipString(); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.checkTotal(0); err != nil {
+		return nil, err
+	}
+	return raw.Bytes(), nil
+}
+
+func (s *limitedScan) skipLong() error {
+	if _, err := readLong(s.cr); err != nil {
+		return s.wrap(err)
+	}
+	return s.checkTotal(0)
+}
+
+// skipString reads over a string, checking its length against the limits first.
+func (s *limitedScan) skipString() error {
+	start := s.cr.n
+	n, err := readLong(s.cr)
+	if err == io.EOF && start == 0 {
+		return io.EOF
+	} else if err != nil {
+		return s.wrap(err)
+	}
+	if n < 0 {
+		return newDecodeError(fmt.Errorf("negative string length %d", n))
+	}
+	if max := s.limits.MaxStringBytes; max > 0 && n > max-s.strBytes {
+		return &Error{Kind: ErrLimitExceeded, Err: fmt.Errorf("MaxStringBytes (%d) exceeded", max)}
+	}
+	s.strBytes += n
+	if err := s.checkTotal(n); err != nil {
+		return err
+	}
+	if _, err := io.CopyN(ioutil.Discard, s.cr, n); err != nil {
+		return s.wrap(err)
+	}
+	return nil
+}
+
+// checkTotal checks that reading another n >= 0 bytes stays within MaxTotalBytes. The sum is
+// never formed, so huge lengths cannot overflow it.
+func (s *limitedScan) checkTotal(n int64) error {
+	if n < 0 {
+		return newDecodeError(fmt.Errorf("negative length %d", n))
+	}
+	if max := s.limits.MaxTotalBytes; max > 0 && (s.cr.n > max || n > max-s.cr.n) {
+		return &Error{Kind: ErrLimitExceeded, Err: fmt.Errorf("MaxTotalBytes (%d) exceeded", max)}
+	}
+	return nil
+}
+
+func (s *limitedScan) wrap(err error) error {
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	return newDecodeError(err)
+}
@@ -0,0 +1,51 @@
+package sfgo
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/actgardner/gogen-avro/v7/vm"
+)
+
+func TestDeserializeContainerLimitedHugeLength(t *testing.T) {
+	// A 1-byte id followed by a name claiming math.MaxInt64 bytes.
+	var buf bytes.Buffer
+	buf.Write([]byte{0x02, 'a'})
+	if err := vm.WriteLong(math.MaxInt64, &buf); err != nil {
+		t.Fatal(err)
+	}
+	buf.WriteString("name")
+	for _, limits := range []DecodeLimits{
+		DefaultDecodeLimits,
+		{MaxStringBytes: 1 << 10},
+		{MaxTotalBytes: 1 << 10},
+	} {
+		_, err := DeserializeContainerLimited(bytes.NewReader(buf.Bytes()), limits)
+		if !errors.Is(err, ErrLimitExceeded) {
+			t.Errorf("%+v: error = %v, want ErrLimitExceeded", limits, err)
+		}
+	}
+}
+
+func TestDeserializeContainerLimited(t *testing.T) {
+	c := decodeTestContainer()
+	b, err := c.AppendBinary(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := DeserializeContainerLimited(bytes.NewReader(b), DefaultDecodeLimits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !d.Equal(c) {
+		t.Errorf("decoded %+v, want %+v", d, c)
+	}
+	if _, err := DeserializeContainerLimited(bytes.NewReader(b), DecodeLimits{MaxTotalBytes: int64(len(b) - 1)}); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("MaxTotalBytes below record size: error = %v, want ErrLimitExceeded", err)
+	}
+	if _, err := DeserializeContainerLimited(bytes.NewReader(b), DecodeLimits{MaxStringBytes: 4}); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("MaxStringBytes below string sizes: error = %v, want ErrLimitExceeded", err)
+	}
+}
@@ -0,0 +1,78 @@
+package sfgo
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// CompileImagePattern compiles a shell glob over image references into a regular expression.
+// '*' matches any sequence of characters (including '/'), '?' matches any single character,
+// '[...]' matches a character class ('[!...]' or '[^...]' negates it), and '\' escapes the next character.
+// Patterns are matched by Unicode code point, not by byte.
+func CompileImagePattern(pattern string, caseSensitive bool) (*regexp.Regexp, error) {
+	var b strings.Builder
+	if !caseSensitive {
+		b.WriteString("(?i)")
+	}
+	b.WriteString("^")
+	for i := 0; i < len(pattern); {
+		c, size := utf8.DecodeRuneInString(pattern[i:])
+		switch c {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		case '\\':
+			if i+size == len(pattern) {
+				return nil, fmt.Errorf("invalid image pattern '%s': trailing escape", pattern)
+			}
+			i += size
+			_, size = utf8.DecodeRuneInString(pattern[i:])
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+size]))
+		case '[':
+			j := i + 1
+			if j < len(pattern) && (pattern[j] == '!' || pattern[j] == '^') {
+				j++
+			}
+			if j < len(pattern) && pattern[j] == ']' {
+				j++
+			}
+			for j < len(pattern) && pattern[j] != ']' {
+				j++
+			}
+			if j == len(pattern) {
+				return nil, fmt.Errorf("invalid image pattern '%s': unterminated character class", pattern)
+			}
+			class := pattern[i+1 : j]
+			b.WriteString("[")
+			if class[0] == '!' || class[0] == '^' {
+				b.WriteString("^")
+				class = class[1:]
+			}
+			b.WriteString(strings.NewReplacer(`\`, `\\`, `[`, `\[`).Replace(class))
+			b.WriteString("]")
+			i, size = j, 1
+		default:
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+size]))
+		}
+		i += size
+	}
+	b.WriteString("$")
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid image pattern '%s': %w", pattern, err)
+	}
+	return re, nil
+}
+
+// ImageMatches checks whether the container image matches a shell glob pattern, ignoring case.
+// See CompileImagePattern for the pattern syntax.
+func (r *Container) ImageMatches(pattern string) (bool, error) {
+	re, err := CompileImagePattern(pattern, false)
+	if err != nil {
+		return false, err
+	}
+	return re.MatchString(r.Image), nil
+}
@@ -0,0 +1,26 @@
+package sfgo
+
+import "testing"
+
+func TestCompileImagePatternRunes(t *testing.T) {
+	for _, tc := range []struct {
+		pattern, image string
+		match          bool
+	}{
+		{"é", "é", true},
+		{"é", "Ã©", false},
+		{"?", "é", true},
+		{"[é]", "é", true},
+		{"[!é]", "é", false},
+		{`\é`, "é", true},
+		{"cafÉ/*", "café/app", true},
+	} {
+		re, err := CompileImagePattern(tc.pattern, false)
+		if err != nil {
+			t.Fatalf("%q: %v", tc.pattern, err)
+		}
+		if got := re.MatchString(tc.image); got != tc.match {
+			t.Errorf("%q on %q: got %v, want %v", tc.pattern, tc.image, got, tc.match)
+		}
+	}
+}
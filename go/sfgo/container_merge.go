@@ -0,0 +1,126 @@
+package sfgo
+
+import (
+	"bufio"
+	"container/heap"
+	"io"
+	"os"
+)
+
+// ContainerMergeReader reads the containers of several sorted OCF files as one ordered stream.
+type ContainerMergeReader struct {
+	files []*os.File
+	h     mergeHeap
+	err   error
+}
+
+// MergeContainerOCF opens the container OCF files and performs a lazy k-way merge over them.
+// Each file must be sorted according to order, which reports whether a sorts before b; containers
+// that compare equal are returned in the order of the files they come from. Only the head record
+// of each file is held in memory.
+func MergeContainerOCF(order func(a, b *Container) bool, files ...string) (*ContainerMergeReader, error) {
+	m := &ContainerMergeReader{h: mergeHeap{order: order}}
+	for i, path := range files {
+		f, err := os.Open(path)
+		if err != nil {
+			m.Close()
+			return nil, err
+		}
+		m.files = append(m.files, f)
+		r, err := NewContainerOCFReader(bufio.NewReader(f))
+		if err != nil {
+			m.Close()
+			return nil, err
+		}
+		src := &mergeSource{r: r, idx: i}
+		if ok, err := src.advance(); err != nil {
+			m.Close()
+			return nil, err
+		} else if ok {
+			m.h.srcs = append(m.h.srcs, src)
+		}
+	}
+	heap.Init(&m.h)
+	return m, nil
+}
+
+// Read returns the next container in merge order, or io.EOF once all files are exhausted. Once a
+// file fails to decode, Read keeps returning the error.
+func (m *ContainerMergeReader) Read() (*Container, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if len(m.h.srcs) == 0 {
+		return nil, io.EOF
+	}
+	src := m.h.srcs[0]
+	c := src.head
+	ok, err := src.advance()
+	if err != nil {
+		// Return the head already read; the error is reported by the next call.
+		m.err = err
+		return c, nil
+	}
+	if ok {
+		heap.Fix(&m.h, 0)
+	} else {
+		heap.Pop(&m.h)
+	}
+	return c, nil
+}
+
+// Close closes the underlying files.
+func (m *ContainerMergeReader) Close() error {
+	var err error
+	for _, f := range m.files {
+		if cerr := f.Close(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	m.files = nil
+	return err
+}
+
+// mergeSource is a file being merged along with its current head record.
+type mergeSource struct {
+	r    *ContainerOCFReader
+	idx  int
+	head *Container
+}
+
+// advance reads the next head record, reporting false at the end of the file.
+func (s *mergeSource) advance() (bool, error) {
+	c, err := s.r.Read()
+	if err == io.EOF {
+		return false, nil
+	} else if err != nil {
+		return false, newDecodeError(err)
+	}
+	s.head = c
+	return true, nil
+}
+
+// mergeHeap orders merge sources by their head records.
+type mergeHeap struct {
+	srcs  []*mergeSource
+	order func(a, b *Container) bool
+}
+
+func (h *mergeHeap) Len() int { return len(h.srcs) }
+func (h *mergeHeap) Less(i, j int) bool {
+	a, b := h.srcs[i], h.srcs[j]
+	if h.order(a.head, b.head) {
+		return true
+	}
+	if h.order(b.head, a.head) {
+		return false
+	}
+	return a.idx < b.idx
+}
+func (h *mergeHeap) Swap(i, j int)      { h.srcs[i], h.srcs[j] = h.srcs[j], h.srcs[i] }
+func (h *mergeHeap) Push(x interface{}) { h.srcs = append(h.srcs, x.(*mergeSource)) }
+func (h *mergeHeap) Pop() interface{} {
+	s := h.srcs[len(h.srcs)-1]
+	h.srcs = h.srcs[:len(h.srcs)-1]
+	return s
+}
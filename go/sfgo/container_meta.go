@@ -0,0 +1,120 @@
+package sfgo
+
+import "time"
+
+// AnnotatedContainer is a container with in-memory extension metadata, such as labels, field
+// sources and an expiry, that is not part of the SysFlow schema and is never serialized. The
+// metadata belongs to the wrapper and is released with it; the embedded container is encoded as
+// usual.
+type AnnotatedContainer struct {
+	*Container
+	meta containerMeta
+}
+
+// containerMeta holds the extension metadata of an annotated container.
+type containerMeta struct {
+	labels       map[string]string
+	sourceSchema string
+	sources      map[int]FieldSource
+	rawType      *int
+	expiry       time.Time
+}
+
+// NewAnnotatedContainer wraps c without metadata.
+func NewAnnotatedContainer(c *Container) *AnnotatedContainer {
+	return &AnnotatedContainer{Container: c}
+}
+
+// SetLabel attaches an in-memory label to the container.
+func (a *AnnotatedContainer) SetLabel(key, value string) {
+	if a.meta.labels == nil {
+		a.meta.labels = make(map[string]string)
+	}
+	a.meta.labels[key] = value
+}
+
+// Label returns the value of an in-memory label.
+func (a *AnnotatedContainer) Label(key string) (string, bool) {
+	v, ok := a.meta.labels[key]
+	return v, ok
+}
+
+// Labels returns a copy of the container's in-memory labels.
+func (a *AnnotatedContainer) Labels() map[string]string {
+	labels := make(map[string]string, len(a.meta.labels))
+	for k, v := range a.meta.labels {
+		labels[k] = v
+	}
+	return labels
+}
+
+// SourceSchema returns the writer schema the container was decoded with, if it was decoded
+// with DeserializeContainerWithSource.
+func (a *AnnotatedContainer) SourceSchema() (string, bool) {
+	return a.meta.sourceSchema, a.meta.sourceSchema != ""
+}
+
+// RawType returns the enum index the container type was decoded from, including out-of-range
+// values, if the container was decoded with DecodeOptions.LenientTypes.
+func (a *AnnotatedContainer) RawType() (int, bool) {
+	if a.meta.rawType == nil {
+		return 0, false
+	}
+	return *a.meta.rawType, true
+}
+
+// SetExpiry sets the time after which the container is considered stale, e.g., by caches. Like
+// all extension metadata, the expiry is in-memory only: Serialize ignores it. The zero time
+// clears the expiry.
+func (a *AnnotatedContainer) SetExpiry(t time.Time) {
+	a.meta.expiry = t
+}
+
+// Expiry returns the expiry time set with SetExpiry.
+func (a *AnnotatedContainer) Expiry() (time.Time, bool) {
+	return a.meta.expiry, !a.meta.expiry.IsZero()
+}
+
+// Expired checks whether the container has an expiry time not after now.
+func (a *AnnotatedContainer) Expired(now time.Time) bool {
+	t, ok := a.Expiry()
+	return ok && !now.Before(t)
+}
+
+// clone returns a deep copy of the metadata.
+func (m containerMeta) clone() containerMeta {
+	if m.labels != nil {
+		labels := make(map[string]string, len(m.labels))
+		for k, v := range m.labels {
+			labels[k] = v
+		}
+		m.labels = labels
+	}
+	if m.sources != nil {
+		sources := make(map[int]FieldSource, len(m.sources))
+		for k, v := range m.sources {
+			sources[k] = v
+		}
+		m.sources = sources
+	}
+	if m.rawType != nil {
+		t := *m.rawType
+		m.rawType = &t
+	}
+	return m
+}
+
+// Clone returns a deep copy of the container.
+func (r *Container) Clone() *Container {
+	c := *r
+	if r.PodId != nil {
+		p := *r.PodId
+		c.PodId = &p
+	}
+	return &c
+}
+
+// Clone returns a deep copy of the container, including its in-memory metadata.
+func (a *AnnotatedContainer) Clone() *AnnotatedContainer {
+	return &AnnotatedContainer{Container: a.Container.Clone(), meta: a.meta.clone()}
+}
@@ -0,0 +1,116 @@
+package sfgo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MigrationSpec declaratively describes a container migration. Fields are referred to by their
+// Avro names; only the string fields id, name, image and imageid can be migrated. Copies are
+// applied first, then defaults, then transforms, each in the listed order.
+type MigrationSpec struct {
+	Copies     []FieldCopy
+	Defaults   map[string]string // values for fields that are absent (empty or NA)
+	Transforms []FieldTransform
+}
+
+// FieldCopy copies the value of one field into another. If IfAbsent is set, the target is only
+// overwritten if it is absent; if Move is set, the source is cleared afterwards (a rename).
+type FieldCopy struct {
+	From, To string
+	IfAbsent bool
+	Move     bool
+}
+
+// FieldTransform applies the named built-in transform (see MigrationTransforms) to a field.
+type FieldTransform struct {
+	Field     string
+	Transform string
+}
+
+// MigrationTransforms are the built-in transforms available to migration specs.
+var MigrationTransforms = map[string]func(string) string{
+	"lowercase":          strings.ToLower,
+	"trim":               strings.TrimSpace,
+	"na-to-empty":        falcoValue,
+	"empty-to-na":        naIfEmpty,
+	"strip-sha256":       func(s string) string { return strings.TrimPrefix(s, "sha256:") },
+	"normalize-registry": NormalizeImageRegistry,
+}
+
+func naIfEmpty(s string) string {
+	if s == "" {
+		return naValue
+	}
+	return s
+}
+
+// Validate checks that the spec only refers to migratable fields and known transforms.
+func (spec MigrationSpec) Validate() error {
+	for _, c := range spec.Copies {
+		if migrationField(nil, c.From) == nil || migrationField(nil, c.To) == nil {
+			return fmt.Errorf("invalid copy from '%s' to '%s': not a migratable field", c.From, c.To)
+		}
+	}
+	for f := range spec.Defaults {
+		if migrationField(nil, f) == nil {
+			return fmt.Errorf("invalid default for '%s': not a migratable field", f)
+		}
+	}
+	for _, t := range spec.Transforms {
+		if migrationField(nil, t.Field) == nil {
+			return fmt.Errorf("invalid transform of '%s': not a migratable field", t.Field)
+		}
+		if _, ok := MigrationTransforms[t.Transform]; !ok {
+			return fmt.Errorf("unknown transform '%s'", t.Transform)
+		}
+	}
+	return nil
+}
+
+// MigrateContainer validates the spec and returns a migrated copy of the container.
+func MigrateContainer(r *Container, spec MigrationSpec) (*Container, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	c := r.Clone()
+	for _, cp := range spec.Copies {
+		from, to := migrationField(c, cp.From), migrationField(c, cp.To)
+		if cp.IfAbsent && !isAbsent(*to) {
+			continue
+		}
+		*to = *from
+		if cp.Move && from != to {
+			*from = ""
+		}
+	}
+	for f, v := range spec.Defaults {
+		if p := migrationField(c, f); isAbsent(*p) {
+			*p = v
+		}
+	}
+	for _, t := range spec.Transforms {
+		p := migrationField(c, t.Field)
+		*p = MigrationTransforms[t.Transform](*p)
+	}
+	return c, nil
+}
+
+// migrationField returns a pointer to the named string field of r, or nil if it is not migratable.
+// If r is nil, it returns a non-nil placeholder for migratable fields.
+func migrationField(r *Container, name string) *string {
+	if r == nil {
+		r = &Container{}
+	}
+	switch name {
+	case ContainerFieldNames[ContainerFieldID]:
+		return &r.Id
+	case ContainerFieldNames[ContainerFieldName]:
+		return &r.Name
+	case ContainerFieldNames[ContainerFieldImage]:
+		return &r.Image
+	case ContainerFieldNames[ContainerFieldImageID]:
+		return &r.Imageid
+	}
+	return nil
+}
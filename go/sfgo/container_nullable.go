@@ -0,0 +1,41 @@
+package sfgo
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+var (
+	containerNullableFields     []int
+	containerNullableFieldsOnce sync.Once
+)
+
+// getContainerNullableFields returns the indices of the container fields whose type is a union with null.
+func getContainerNullableFields() []int {
+	containerNullableFieldsOnce.Do(func() {
+		var s struct {
+			Fields []struct {
+				Type interface{} `json:"type"`
+			} `json:"fields"`
+		}
+		if err := json.Unmarshal([]byte(NewContainer().Schema()), &s); err != nil {
+			panic(err)
+		}
+		for i, f := range s.Fields {
+			branches, _ := f.Type.([]interface{})
+			for _, b := range branches {
+				if b == "null" {
+					containerNullableFields = append(containerNullableFields, i)
+					break
+				}
+			}
+		}
+	})
+	return containerNullableFields
+}
+
+// NullableFields returns the indices of the fields accepted by NullField, in schema order; it is
+// derived from the schema and currently holds only ContainerFieldPodID. The result may be modified.
+func (r *Container) NullableFields() []int {
+	return append([]int(nil), getContainerNullableFields()...)
+}
@@ -0,0 +1,302 @@
+package sfgo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ContainerOCFFS exposes the containers stored in the OCF file at path as a read-only file system.
+// Each record is a JSON file named "<id>.json" at the root (ids are path-escaped, and duplicates
+// get a "-<n>" suffix). The file listing is built on first access from the record ids alone.
+// Opening a file decodes its record only, from the last decompressed block, which is cached; the
+// sizes of all files are computed in one pass over the blocks when the Info of a directory entry
+// is first requested. Decoded records are not retained. The returned file system implements
+// io.Closer to release the mapped file.
+func ContainerOCFFS(path string) (fs.FS, error) {
+	idx, err := NewContainerOCFIndex(path)
+	if err != nil {
+		return nil, err
+	}
+	return &containerOCFFS{idx: idx, cached: -1}, nil
+}
+
+type containerOCFFS struct {
+	idx     *ContainerOCFIndex
+	once    sync.Once
+	err     error
+	entries []ocfFSEntry
+	byName  map[string]int
+
+	sizesOnce sync.Once
+	sizesErr  error
+	sizes     []int64 // by entry
+
+	mu     sync.Mutex // guards the cached block
+	cached int
+	raw    []byte
+}
+
+type ocfFSEntry struct {
+	name  string
+	block int
+	off   int // offset of the record in the decompressed block
+	size  int64
+}
+
+func (f *containerOCFFS) load() error {
+	f.once.Do(func() {
+		f.byName = make(map[string]int)
+		for b := 0; b < f.idx.BlockCount(); b++ {
+			ids, offs, err := f.blockIDs(b)
+			if err != nil {
+				f.err = err
+				return
+			}
+			for p, id := range ids {
+				base := url.PathEscape(id)
+				if base == "" || base == "." || base == ".." {
+					base = "_" + base
+				}
+				name := base + ".json"
+				for n := 1; ; n++ {
+					if _, dup := f.byName[name]; !dup {
+						break
+					}
+					name = fmt.Sprintf("%s-%d.json", base, n)
+				}
+				f.byName[name] = len(f.entries)
+				f.entries = append(f.entries, ocfFSEntry{name: name, block: b, off: offs[p]})
+			}
+		}
+		sort.Slice(f.entries, func(i, j int) bool { return f.entries[i].name < f.entries[j].name })
+		for i, e := range f.entries {
+			f.byName[e.name] = i
+		}
+	})
+	return f.err
+}
+
+// blockIDs returns the ids of the containers in the b-th block and their offsets in the
+// decompressed block. Ids are peeked without decoding the records, unless the file was written
+// with a different schema.
+func (f *containerOCFFS) blockIDs(b int) ([]string, []int, error) {
+	blk, raw, err := f.idx.readBlock(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	peek := isContainerSchema(string(f.idx.header.schema))
+	var ids []string
+	var offs []int
+	for off, i := 0, int64(0); i < blk.NumRecords; i++ {
+		id, n := "", 0
+		if peek {
+			if id, n, err = PeekContainerID(raw[off:]); err != nil {
+				return nil, nil, err
+			}
+		} else {
+			r := bytes.NewReader(raw[off:])
+			t := NewContainer()
+			if err := evalContainer(r, f.idx.prog, t); err != nil {
+				return nil, nil, err
+			}
+			id, n = t.Id, len(raw)-off-r.Len()
+		}
+		ids = append(ids, id)
+		offs = append(offs, off)
+		off += n
+	}
+	return ids, offs, nil
+}
+
+// blockData returns the decompressed b-th block, caching the last one.
+func (f *containerOCFFS) blockData(b int) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.cached == b {
+		return f.raw, nil
+	}
+	_, raw, err := f.idx.readBlock(b)
+	if err != nil {
+		return nil, err
+	}
+	f.cached, f.raw = b, raw
+	return raw, nil
+}
+
+// Open opens the named file or the root directory ".".
+func (f *containerOCFFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	if err := f.load(); err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	if name == "." {
+		return &ocfFSDir{fs: f}, nil
+	}
+	i, ok := f.byName[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	e := f.entries[i]
+	data, err := f.record(e)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	e.size = int64(len(data))
+	return &ocfFSFile{info: ocfFSInfo{entry: e}, r: bytes.NewReader(data)}, nil
+}
+
+// record decodes the record of an entry and returns its content.
+func (f *containerOCFFS) record(e ocfFSEntry) ([]byte, error) {
+	raw, err := f.blockData(e.block)
+	if err != nil {
+		return nil, err
+	}
+	t := NewContainer()
+	if err := evalContainer(bytes.NewReader(raw[e.off:]), f.idx.prog, t); err != nil {
+		return nil, err
+	}
+	if err := enrichContainer(t); err != nil {
+		return nil, err
+	}
+	return json.Marshal(t)
+}
+
+// computeSizes computes the sizes of all entries at once, visiting the blocks in file order.
+func (f *containerOCFFS) computeSizes() error {
+	f.sizesOnce.Do(func() {
+		sizes := make([]int64, len(f.entries))
+		order := make([]int, len(f.entries))
+		for i := range order {
+			order[i] = i
+		}
+		sort.Slice(order, func(i, j int) bool {
+			a, b := f.entries[order[i]], f.entries[order[j]]
+			return a.block < b.block || a.block == b.block && a.off < b.off
+		})
+		for _, i := range order {
+			data, err := f.record(f.entries[i])
+			if err != nil {
+				f.sizesErr = err
+				return
+			}
+			sizes[i] = int64(len(data))
+		}
+		f.sizes = sizes
+	})
+	return f.sizesErr
+}
+
+// ocfFSDirEntry is a listed record file, whose info is computed on demand.
+type ocfFSDirEntry struct {
+	fs *containerOCFFS
+	i  int
+}
+
+func (d ocfFSDirEntry) Name() string      { return d.fs.entries[d.i].name }
+func (d ocfFSDirEntry) IsDir() bool       { return false }
+func (d ocfFSDirEntry) Type() fs.FileMode { return 0 }
+func (d ocfFSDirEntry) String() string    { return fs.FormatDirEntry(d) }
+
+// Info returns the file info, computing the sizes of all files on first use.
+func (d ocfFSDirEntry) Info() (fs.FileInfo, error) {
+	if err := d.fs.computeSizes(); err != nil {
+		return nil, err
+	}
+	e := d.fs.entries[d.i]
+	e.size = d.fs.sizes[d.i]
+	return ocfFSInfo{entry: e}, nil
+}
+
+// ReadDir lists the root directory.
+func (f *containerOCFFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if name != "." {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+	if err := f.load(); err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+	}
+	des := make([]fs.DirEntry, len(f.entries))
+	for i := range f.entries {
+		des[i] = ocfFSDirEntry{fs: f, i: i}
+	}
+	return des, nil
+}
+
+// Close releases the mapped OCF file.
+func (f *containerOCFFS) Close() error {
+	f.mu.Lock()
+	f.cached, f.raw = -1, nil
+	f.mu.Unlock()
+	return f.idx.Close()
+}
+
+// ocfFSInfo describes a record file, or the root directory if dir is set.
+type ocfFSInfo struct {
+	entry ocfFSEntry
+	dir   bool
+}
+
+func (i ocfFSInfo) Name() string {
+	if i.dir {
+		return "."
+	}
+	return i.entry.name
+}
+func (i ocfFSInfo) Size() int64 { return i.entry.size }
+func (i ocfFSInfo) Mode() fs.FileMode {
+	if i.dir {
+		return fs.ModeDir | 0555
+	}
+	return 0444
+}
+func (i ocfFSInfo) ModTime() time.Time { return time.Time{} }
+func (i ocfFSInfo) IsDir() bool        { return i.dir }
+func (i ocfFSInfo) Sys() interface{}   { return nil }
+
+type ocfFSFile struct {
+	info ocfFSInfo
+	r    *bytes.Reader
+}
+
+func (f *ocfFSFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *ocfFSFile) Read(b []byte) (int, error) { return f.r.Read(b) }
+func (f *ocfFSFile) Seek(off int64, whence int) (int64, error) {
+	return f.r.Seek(off, whence)
+}
+func (f *ocfFSFile) Close() error { return nil }
+
+type ocfFSDir struct {
+	fs  *containerOCFFS
+	off int
+}
+
+func (d *ocfFSDir) Stat() (fs.FileInfo, error) { return ocfFSInfo{dir: true}, nil }
+func (d *ocfFSDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: ".", Err: fs.ErrInvalid}
+}
+func (d *ocfFSDir) Close() error { return nil }
+
+func (d *ocfFSDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	rest := d.fs.entries[d.off:]
+	if n > 0 && len(rest) == 0 {
+		return nil, io.EOF
+	}
+	if n > 0 && n < len(rest) {
+		rest = rest[:n]
+	}
+	des := make([]fs.DirEntry, len(rest))
+	for i := range rest {
+		des[i] = ocfFSDirEntry{fs: d.fs, i: d.off + i}
+	}
+	d.off += len(rest)
+	return des, nil
+}
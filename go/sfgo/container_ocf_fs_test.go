@@ -0,0 +1,52 @@
+package sfgo
+
+import (
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestContainerOCFFS(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "containers.avro")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := NewContainerOCFWriter(f, "deflate", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"c", "a", "b", "a", "d"} {
+		c := decodeTestContainer()
+		c.Id = id
+		if err := w.WriteRecord(c); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	fsys, err := ContainerOCFFS(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fsys.(io.Closer).Close()
+	if err := fstest.TestFS(fsys, "a.json", "a-1.json", "b.json", "c.json", "d.json"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := fs.ReadFile(fsys, "d.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var c Container
+	if err := json.Unmarshal(data, &c); err != nil || c.Id != "d" {
+		t.Errorf("d.json = %s, %v", data, err)
+	}
+}
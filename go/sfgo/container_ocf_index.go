@@ -0,0 +1,166 @@
+package sfgo
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/actgardner/gogen-avro/v7/vm"
+)
+
+// OCFBlock describes the location of a data block in an Avro object container file.
+type OCFBlock struct {
+	Offset     int64 // offset of the block's record count
+	NumRecords int64
+	DataOffset int64 // offset of the (compressed) record bytes
+	Size       int64 // size of the (compressed) record bytes
+}
+
+// ContainerOCFIndex provides random access to the blocks of a memory-mapped container OCF file.
+// Blocks can be decoded concurrently.
+type ContainerOCFIndex struct {
+	data   []byte
+	unmap  func() error
+	header *ocfHeader
+	prog   *vm.Program
+	blocks []OCFBlock
+}
+
+// NewContainerOCFIndex maps the container OCF file at path into memory and indexes its blocks
+// by scanning the sync markers once.
+func NewContainerOCFIndex(path string) (*ContainerOCFIndex, error) {
+	data, unmap, err := mmapFile(path)
+	if err != nil {
+		return nil, err
+	}
+	idx, err := newContainerOCFIndex(data)
+	if err != nil {
+		unmap()
+		return nil, err
+	}
+	idx.unmap = unmap
+	return idx, nil
+}
+
+func newContainerOCFIndex(data []byte) (*ContainerOCFIndex, error) {
+	br := bytes.NewReader(data)
+	header, err := readOCFHeader(br)
+	if err != nil {
+		return nil, err
+	}
+	prog, err := getContainerProgramFor(header.schema)
+	if err != nil {
+		return nil, err
+	}
+	idx := &ContainerOCFIndex{data: data, header: header, prog: prog}
+	off := int64(len(data) - br.Len())
+	for off < int64(len(data)) {
+		blk, next, err := scanOCFBlock(data, off, header.sync)
+		if err != nil {
+			return nil, err
+		}
+		idx.blocks = append(idx.blocks, blk)
+		off = next
+	}
+	return idx, nil
+}
+
+// scanOCFBlock locates the block starting at off and returns it along with the offset of the next block.
+func scanOCFBlock(data []byte, off int64, sync [ocfSyncSize]byte) (OCFBlock, int64, error) {
+	blk := OCFBlock{Offset: off}
+	count, n, err := decodeLong(data[off:])
+	if err != nil {
+		return blk, 0, newDecodeError(fmt.Errorf("reading block count at offset %d: %w", off, err))
+	}
+	size, m, err := decodeLong(data[off+int64(n):])
+	if err != nil {
+		return blk, 0, newDecodeError(fmt.Errorf("reading block size at offset %d: %w", off, err))
+	}
+	if count < 0 || size < 0 {
+		return blk, 0, newDecodeError(fmt.Errorf("invalid block header at offset %d", off))
+	}
+	blk.NumRecords = count
+	blk.DataOffset = off + int64(n+m)
+	blk.Size = size
+	end := blk.DataOffset + size
+	if end+ocfSyncSize > int64(len(data)) || end < blk.DataOffset {
+		return blk, 0, &Error{Kind: ErrTruncated, Err: fmt.Errorf("block at offset %d exceeds file size", off)}
+	}
+	if !bytes.Equal(data[end:end+ocfSyncSize], sync[:]) {
+		return blk, 0, newDecodeError(fmt.Errorf("unexpected sync marker at offset %d", end))
+	}
+	return blk, end + ocfSyncSize, nil
+}
+
+// BlockCount returns the number of data blocks in the file.
+func (idx *ContainerOCFIndex) BlockCount() int {
+	return len(idx.blocks)
+}
+
+// Block returns the location of the i-th block.
+func (idx *ContainerOCFIndex) Block(i int) (OCFBlock, error) {
+	if err := idx.checkBlock(i); err != nil {
+		return OCFBlock{}, err
+	}
+	return idx.blocks[i], nil
+}
+
+// DecodeBlock decodes all containers stored in the i-th block.
+func (idx *ContainerOCFIndex) DecodeBlock(i int) ([]*Container, error) {
+	blk, raw, err := idx.readBlock(i)
+	if err != nil {
+		return nil, err
+	}
+	prealloc := blk.NumRecords
+	if prealloc > maxBatchPrealloc {
+		prealloc = maxBatchPrealloc
+	}
+	r := bytes.NewReader(raw)
+	cs := make([]*Container, 0, prealloc)
+	for j := int64(0); j < blk.NumRecords; j++ {
+		t := NewContainer()
+		if err := evalContainer(r, idx.prog, t); err != nil {
+			return cs, newDecodeError(err)
+		}
+		if err := enrichContainer(t); err != nil {
+			return cs, err
+		}
+		cs = append(cs, t)
+	}
+	return cs, nil
+}
+
+// readBlock returns the location and the decompressed record bytes of the i-th block.
+func (idx *ContainerOCFIndex) readBlock(i int) (OCFBlock, []byte, error) {
+	if err := idx.checkBlock(i); err != nil {
+		return OCFBlock{}, nil, err
+	}
+	blk := idx.blocks[i]
+	raw, err := decompressBlock(idx.header.codec, idx.data[blk.DataOffset:blk.DataOffset+blk.Size])
+	if err != nil {
+		return blk, nil, newDecodeError(err)
+	}
+	return blk, raw, nil
+}
+
+// checkBlock checks that the index is open and i is a block index.
+func (idx *ContainerOCFIndex) checkBlock(i int) error {
+	if idx.data == nil {
+		return errors.New("container OCF index closed")
+	}
+	if i < 0 || i >= len(idx.blocks) {
+		return &Error{Kind: ErrOutOfRange, Err: fmt.Errorf("block index %d not in [0, %d)", i, len(idx.blocks))}
+	}
+	return nil
+}
+
+// Close unmaps the underlying file.
+func (idx *ContainerOCFIndex) Close() error {
+	if idx.unmap == nil {
+		return errors.New("container OCF index already closed")
+	}
+	err := idx.unmap()
+	idx.unmap = nil
+	idx.data = nil
+	return err
+}
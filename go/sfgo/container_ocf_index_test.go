@@ -0,0 +1,88 @@
+package sfgo
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/actgardner/gogen-avro/v7/vm"
+)
+
+// writeTestOCF returns a container OCF file holding n containers in one block.
+func writeTestOCF(t *testing.T, n int) []byte {
+	var buf bytes.Buffer
+	w, err := NewContainerOCFWriter(&buf, "null", n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < n; i++ {
+		if err := w.WriteRecord(decodeTestContainer()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestContainerOCFIndexCorruptCount(t *testing.T) {
+	data := writeTestOCF(t, 2)
+	idx, err := newContainerOCFIndex(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blk, err := idx.Block(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Replace the block's record count with math.MaxInt64.
+	var corrupt bytes.Buffer
+	corrupt.Write(data[:blk.Offset])
+	if err := vm.WriteLong(math.MaxInt64, &corrupt); err != nil {
+		t.Fatal(err)
+	}
+	corrupt.Write(data[blk.Offset+1:])
+	idx, err = newContainerOCFIndex(corrupt.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cs, err := idx.DecodeBlock(0)
+	if err == nil {
+		t.Fatal("DecodeBlock succeeded on a block with a corrupt record count")
+	}
+	if len(cs) != 2 {
+		t.Errorf("decoded %d containers before failing, want 2", len(cs))
+	}
+}
+
+func TestContainerOCFIndexBlockErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "containers.avro")
+	if err := os.WriteFile(path, writeTestOCF(t, 1), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	idx, err := NewContainerOCFIndex(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, i := range []int{-1, idx.BlockCount()} {
+		if _, err := idx.Block(i); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("Block(%d) error = %v, want ErrOutOfRange", i, err)
+		}
+		if _, err := idx.DecodeBlock(i); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("DecodeBlock(%d) error = %v, want ErrOutOfRange", i, err)
+		}
+	}
+	if err := idx.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := idx.Block(0); err == nil {
+		t.Error("Block succeeded after Close")
+	}
+	if _, err := idx.DecodeBlock(0); err == nil {
+		t.Error("DecodeBlock succeeded after Close")
+	}
+}
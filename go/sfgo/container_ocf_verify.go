@@ -0,0 +1,222 @@
+package sfgo
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+
+	"github.com/actgardner/gogen-avro/v7/vm"
+)
+
+// BlockState is the verification outcome of an OCF block.
+type BlockState int
+
+// BlockState enumeration.
+const (
+	BlockOK BlockState = iota
+	BlockCorrupt
+)
+
+func (s BlockState) String() string {
+	switch s {
+	case BlockOK:
+		return "OK"
+	case BlockCorrupt:
+		return "Corrupt"
+	}
+	return fmt.Sprintf("BlockState(%d)", s)
+}
+
+// BlockStatus describes a verified OCF block.
+type BlockStatus struct {
+	Offset     int64 // offset of the block's record count
+	NumRecords int64 // declared record count, 0 if the block header is unreadable
+	State      BlockState
+	Err        error // cause of the corruption
+}
+
+// VerifyContainerOCF walks the blocks of a container OCF stream and checks each block's header and
+// sync marker, and that its records decode and fill the block exactly. A corrupt block does not
+// end the walk: verification resumes after the next sync marker found in the stream, so a single
+// damaged block costs one status entry rather than the rest of the file. The returned error
+// reports an unreadable stream or file header; corruption is only reported in the statuses.
+//
+// The stream is read sequentially, holding one block in memory at a time. The search for the next
+// sync marker starts within the bytes read for the corrupt block, which maxOCFBlockSize bounds,
+// and continues in the stream with a window of one marker.
+func VerifyContainerOCF(r io.Reader) ([]BlockStatus, error) {
+	in := &ocfResyncReader{r: bufio.NewReader(r)}
+	hdr := &countingReader{r: in}
+	header, err := readOCFHeader(hdr)
+	if err != nil {
+		return nil, err
+	}
+	prog, err := getContainerProgramFor(header.schema)
+	if err != nil {
+		return nil, err
+	}
+	var res []BlockStatus
+	var cur bytes.Buffer
+	for off := hdr.n; ; {
+		cur.Reset()
+		src := &countingReader{r: io.TeeReader(in, &cur), n: off}
+		count, data, err := readOCFBlock(src, header.sync)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			res = append(res, BlockStatus{Offset: off, NumRecords: count, State: BlockCorrupt, Err: err})
+			n, ok, err := in.resync(cur.Bytes(), header.sync)
+			if err != nil {
+				return res, err
+			}
+			if !ok {
+				break
+			}
+			off += n
+			continue
+		}
+		st := BlockStatus{Offset: off, NumRecords: count}
+		if err := verifyOCFBlock(data, count, header.codec, prog); err != nil {
+			st.State, st.Err = BlockCorrupt, err
+		}
+		res = append(res, st)
+		off = src.n
+	}
+	return res, nil
+}
+
+// ocfResyncReader reads an OCF stream, serving bytes pushed back by resync before those of r.
+type ocfResyncReader struct {
+	pending []byte
+	r       *bufio.Reader
+}
+
+func (s *ocfResyncReader) Read(p []byte) (int, error) {
+	if len(s.pending) > 0 {
+		n := copy(p, s.pending)
+		s.pending = s.pending[n:]
+		return n, nil
+	}
+	return s.r.Read(p)
+}
+
+func (s *ocfResyncReader) ReadByte() (byte, error) {
+	if len(s.pending) > 0 {
+		b := s.pending[0]
+		s.pending = s.pending[1:]
+		return b, nil
+	}
+	return s.r.ReadByte()
+}
+
+// resync positions the stream after the first sync marker following a corrupt block, whose bytes
+// read so far are consumed, and returns the number of bytes skipped from the block's start. If the
+// marker is in consumed, the bytes after it are pushed back; otherwise the stream is scanned for
+// it. It returns false if the stream ends without another marker.
+func (s *ocfResyncReader) resync(consumed []byte, sync [ocfSyncSize]byte) (int64, bool, error) {
+	if i := bytes.Index(consumed, sync[:]); i >= 0 {
+		tail := consumed[i+ocfSyncSize:]
+		s.pending = append(append(make([]byte, 0, len(tail)+len(s.pending)), tail...), s.pending...)
+		return int64(i + ocfSyncSize), true, nil
+	}
+	// The marker may start within the last bytes consumed.
+	keep := len(consumed)
+	if keep > ocfSyncSize-1 {
+		keep = ocfSyncSize - 1
+	}
+	window := append(make([]byte, 0, ocfSyncSize), consumed[len(consumed)-keep:]...)
+	n := int64(len(consumed) - keep)
+	for {
+		b, err := s.ReadByte()
+		if err == io.EOF {
+			return 0, false, nil
+		} else if err != nil {
+			return 0, false, err
+		}
+		if len(window) == ocfSyncSize {
+			copy(window, window[1:])
+			window = window[:ocfSyncSize-1]
+			n++
+		}
+		window = append(window, b)
+		if len(window) == ocfSyncSize && bytes.Equal(window, sync[:]) {
+			return n + ocfSyncSize, true, nil
+		}
+	}
+}
+
+// verifyOCFBlock checks that the block data decompresses to exactly count records, or is a
+// checksum footer.
+func verifyOCFBlock(data []byte, count int64, codec string, prog *vm.Program) error {
+	raw, err := decompressBlock(codec, data)
+	if err != nil {
+		return newDecodeError(err)
+	}
+	if count == 0 && isOCFChecksumFooter(raw) {
+		return nil
+	}
+	r := bytes.NewReader(raw)
+	for i := int64(0); i < count; i++ {
+		if err := evalContainer(r, prog, NewContainer()); err != nil {
+			return newDecodeError(fmt.Errorf("record %d: %w", i, err))
+		}
+	}
+	if r.Len() > 0 {
+		return newDecodeError(fmt.Errorf("%d unexpected bytes after %d records", r.Len(), count))
+	}
+	return nil
+}
+
+// ocfChecksumMagic starts the payload of an OCF checksum footer (see ContainerOCFWriter.Checksum).
+const ocfChecksumMagic = "SFSHA256"
+
+// isOCFChecksumFooter checks whether the decompressed payload of a block is a checksum footer.
+func isOCFChecksumFooter(raw []byte) bool {
+	return len(raw) == len(ocfChecksumMagic)+sha256.Size && string(raw[:len(ocfChecksumMagic)]) == ocfChecksumMagic
+}
+
+// VerifyContainerOCFChecksum checks the SHA-256 footer of a container OCF stream written with
+// ContainerOCFWriter.Checksum, returning an ErrCRCMismatch error if the content does not match the
+// digest, or an ErrDecode error if the stream is malformed or has no footer as its last block.
+// The stream is hashed as it is read, holding only the last block in memory.
+func VerifyContainerOCFChecksum(r io.Reader) error {
+	sum := sha256.New()
+	br := bufio.NewReader(r)
+	header, err := readOCFHeader(io.TeeReader(br, sum))
+	if err != nil {
+		return err
+	}
+	// The footer digest covers all bytes preceding it, so each block is hashed only once the next
+	// one is found.
+	var cur bytes.Buffer
+	var last, data []byte
+	var count int64
+	src := &countingReader{r: io.TeeReader(br, &cur)}
+	for {
+		n, d, err := readOCFBlock(src, header.sync)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		sum.Write(last)
+		last = append(last[:0], cur.Bytes()...)
+		cur.Reset()
+		count, data = n, d
+	}
+	if len(last) > 0 && count == 0 {
+		raw, err := decompressBlock(header.codec, data)
+		if err != nil {
+			return newDecodeError(err)
+		}
+		if isOCFChecksumFooter(raw) {
+			if digest := sum.Sum(nil); !bytes.Equal(digest, raw[len(ocfChecksumMagic):]) {
+				return &Error{Kind: ErrCRCMismatch, Err: fmt.Errorf("SHA-256 %x, expected %x", digest, raw[len(ocfChecksumMagic):])}
+			}
+			return nil
+		}
+	}
+	return &Error{Kind: ErrDecode, Err: fmt.Errorf("missing checksum footer")}
+}
@@ -0,0 +1,66 @@
+package sfgo
+
+import (
+	"bytes"
+	"testing"
+	"testing/iotest"
+)
+
+// writeTestOCFBlocks returns a container OCF file with one container per block, and its blocks.
+func writeTestOCFBlocks(t *testing.T, n int) ([]byte, []OCFBlock) {
+	var buf bytes.Buffer
+	w, err := NewContainerOCFWriter(&buf, "null", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < n; i++ {
+		if err := w.WriteRecord(decodeTestContainer()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	idx, err := newContainerOCFIndex(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes(), idx.blocks
+}
+
+func TestVerifyContainerOCFResync(t *testing.T) {
+	clean, blocks := writeTestOCFBlocks(t, 4)
+	tests := []struct {
+		name    string
+		corrupt func(b []byte)
+		want    []BlockState
+		offsets []int
+	}{
+		{"clean", func(b []byte) {}, []BlockState{BlockOK, BlockOK, BlockOK, BlockOK}, []int{0, 1, 2, 3}},
+		// The id length exceeds the block.
+		{"record", func(b []byte) { b[blocks[1].DataOffset] = 0x7e }, []BlockState{BlockOK, BlockCorrupt, BlockOK, BlockOK}, []int{0, 1, 2, 3}},
+		// The block claims one more byte, so its own marker is found among the bytes read.
+		{"size", func(b []byte) { b[blocks[1].DataOffset-1] += 2 }, []BlockState{BlockOK, BlockCorrupt, BlockOK, BlockOK}, []int{0, 1, 2, 3}},
+		// The next marker is that of block 2, so verification resumes at block 3.
+		{"sync", func(b []byte) { b[blocks[1].DataOffset+blocks[1].Size] ^= 1 }, []BlockState{BlockOK, BlockCorrupt, BlockOK}, []int{0, 1, 3}},
+		{"last sync", func(b []byte) { b[len(b)-1] ^= 1 }, []BlockState{BlockOK, BlockOK, BlockOK, BlockCorrupt}, []int{0, 1, 2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := append([]byte{}, clean...)
+			tt.corrupt(data)
+			res, err := VerifyContainerOCF(iotest.OneByteReader(bytes.NewReader(data)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(res) != len(tt.want) {
+				t.Fatalf("got %d statuses %+v, want %d", len(res), res, len(tt.want))
+			}
+			for i, st := range res {
+				if st.State != tt.want[i] || st.Offset != blocks[tt.offsets[i]].Offset || st.NumRecords != 1 {
+					t.Errorf("status %d = %+v, want %v at offset %d", i, st, tt.want[i], blocks[tt.offsets[i]].Offset)
+				}
+			}
+		})
+	}
+}
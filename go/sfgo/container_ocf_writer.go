@@ -0,0 +1,171 @@
+package sfgo
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"hash"
+	"io"
+
+	"github.com/actgardner/gogen-avro/v7/container/avro"
+	"github.com/actgardner/gogen-avro/v7/vm"
+)
+
+// Default block limits of ContainerOCFWriter.
+const (
+	defaultRecordsPerBlock = 1000
+	defaultMaxBlockBytes   = 1 << 20
+)
+
+// ContainerOCFWriter writes containers to an Avro object container file, compressing blocks
+// with any registered Codec.
+type ContainerOCFWriter struct {
+	// MaxBlockRecords is the number of records after which a block is written (see
+	// NewContainerOCFWriter); a non-positive value disables the limit.
+	MaxBlockRecords int
+	// MaxBlockBytes bounds the uncompressed size of a block: a block is written before a record
+	// would make it exceed the limit (a single larger record makes up a block of its own).
+	// It defaults to 1 MiB; a non-positive value disables the limit.
+	MaxBlockBytes int
+	// Checksum makes Close append a footer with the SHA-256 of the file (see
+	// VerifyContainerOCFChecksum). It must be set before the first block is written.
+	//
+	// Standard OCF has no footer; the footer is a non-standard extension encoded as a trailing
+	// block of zero records, whose (compressed) payload is the marker "SFSHA256" followed by the
+	// digest of all bytes preceding the block. Standard readers thus skip it as an empty block.
+	Checksum bool
+
+	w      io.Writer
+	codec  Codec
+	sync   [ocfSyncSize]byte
+	header []byte
+	sum    hash.Hash
+	blocks int
+	block  bytes.Buffer
+	count  int
+	closed bool
+}
+
+// NewContainerOCFWriter creates an OCF writer compressing blocks with the named codec and writes
+// the file header to w. A block is written every recordsPerBlock records (1000 if not positive)
+// or when reaching MaxBlockBytes, whichever comes first.
+func NewContainerOCFWriter(w io.Writer, codec string, recordsPerBlock int) (*ContainerOCFWriter, error) {
+	c, err := getCodec(codec)
+	if err != nil {
+		return nil, err
+	}
+	if recordsPerBlock <= 0 {
+		recordsPerBlock = defaultRecordsPerBlock
+	}
+	cw := &ContainerOCFWriter{MaxBlockRecords: recordsPerBlock, MaxBlockBytes: defaultMaxBlockBytes, w: w, codec: c}
+	if _, err := rand.Read(cw.sync[:]); err != nil {
+		return nil, err
+	}
+	header := &avro.AvroContainerHeader{
+		Magic: ocfMagic,
+		Meta: map[string][]byte{
+			ocfSchemaKey: []byte(NewContainer().Schema()),
+			ocfCodecKey:  []byte(c.Name()),
+		},
+		Sync: cw.sync,
+	}
+	var hb bytes.Buffer
+	if err := header.Serialize(&hb); err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(hb.Bytes()); err != nil {
+		return nil, err
+	}
+	cw.header = hb.Bytes()
+	return cw, nil
+}
+
+// WriteRecord appends a container to the current block, writing blocks as they fill up.
+func (cw *ContainerOCFWriter) WriteRecord(c *Container) error {
+	if cw.closed {
+		return errors.New("container OCF writer already closed")
+	}
+	if cw.MaxBlockBytes > 0 && cw.count > 0 && cw.block.Len()+c.EncodedSize() > cw.MaxBlockBytes {
+		if err := cw.Flush(); err != nil {
+			return err
+		}
+	}
+	n := cw.block.Len()
+	if err := c.Serialize(&cw.block); err != nil {
+		cw.block.Truncate(n)
+		return err
+	}
+	cw.count++
+	if cw.MaxBlockRecords > 0 && cw.count >= cw.MaxBlockRecords {
+		return cw.Flush()
+	}
+	return nil
+}
+
+// Flush writes the current block, if it holds any records.
+func (cw *ContainerOCFWriter) Flush() error {
+	if cw.count == 0 {
+		return nil
+	}
+	if cw.Checksum && cw.sum == nil {
+		if cw.blocks > 0 {
+			return errors.New("container OCF checksum enabled after writing blocks")
+		}
+		cw.sum = sha256.New()
+		cw.sum.Write(cw.header)
+	}
+	w := cw.w
+	if cw.sum != nil {
+		w = io.MultiWriter(cw.w, cw.sum)
+	}
+	if err := cw.writeBlock(w, int64(cw.count), cw.block.Bytes()); err != nil {
+		return err
+	}
+	cw.blocks++
+	cw.block.Reset()
+	cw.count = 0
+	return nil
+}
+
+// writeBlock compresses and writes a block of count records encoded as raw.
+func (cw *ContainerOCFWriter) writeBlock(w io.Writer, count int64, raw []byte) error {
+	data, err := cw.codec.Compress(raw)
+	if err != nil {
+		return err
+	}
+	if err := vm.WriteLong(count, w); err != nil {
+		return err
+	}
+	if err := vm.WriteLong(int64(len(data)), w); err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	_, err = w.Write(cw.sync[:])
+	return err
+}
+
+// Close flushes the last block and writes the checksum footer if enabled. It does not close the
+// underlying writer.
+func (cw *ContainerOCFWriter) Close() error {
+	if cw.closed {
+		return nil
+	}
+	cw.closed = true
+	if err := cw.Flush(); err != nil {
+		return err
+	}
+	if !cw.Checksum {
+		return nil
+	}
+	if cw.sum == nil {
+		if cw.blocks > 0 {
+			return errors.New("container OCF checksum enabled after writing blocks")
+		}
+		cw.sum = sha256.New()
+		cw.sum.Write(cw.header)
+	}
+	return cw.writeBlock(cw.w, 0, cw.sum.Sum([]byte(ocfChecksumMagic)))
+}
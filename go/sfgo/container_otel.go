@@ -0,0 +1,44 @@
+package sfgo
+
+// OpenTelemetry semantic convention attribute names of containers.
+const (
+	OTelContainerID         = "container.id"
+	OTelContainerName       = "container.name"
+	OTelContainerImageName  = "container.image.name"
+	OTelContainerImageTags  = "container.image.tags"
+	OTelContainerImageID    = "container.image.id"
+	OTelContainerRuntime    = "container.runtime"
+	OTelK8sPodUID           = "k8s.pod.uid"
+	OTelContainerPrivileged = "sysflow.container.privileged"
+)
+
+// OTelAttributes returns the container attributes keyed by OpenTelemetry semantic convention
+// names, with values of the attribute types OTLP supports (string, bool and []string), so they
+// can be copied into any OTel SDK or pdata attribute map without depending on OTel here; the
+// sfotel package builds pdata logs from them. The privileged flag has no semantic convention and
+// uses the sysflow namespace. Absent (empty or NA) values are omitted, and the image name and tags
+// are taken from the parsed image reference when possible.
+func (r *Container) OTelAttributes() map[string]interface{} {
+	m := map[string]interface{}{OTelContainerPrivileged: r.Privileged}
+	set := func(k, v string) {
+		if !isAbsent(v) {
+			m[k] = v
+		}
+	}
+	set(OTelContainerID, r.Id)
+	set(OTelContainerName, r.Name)
+	if ref, err := ParseImageRef(r.Image); err == nil && !isAbsent(r.Image) {
+		set(OTelContainerImageName, ref.Repository())
+		if ref.Tag != "" {
+			m[OTelContainerImageTags] = []string{ref.Tag}
+		}
+	} else {
+		set(OTelContainerImageName, r.Image)
+	}
+	set(OTelContainerImageID, r.Imageid)
+	set(OTelContainerRuntime, r.Type.FalcoType())
+	if r.PodId != nil {
+		set(OTelK8sPodUID, r.PodId.String)
+	}
+	return m
+}
@@ -0,0 +1,31 @@
+package sfgo
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// jsonPatchOp is an RFC 6902 JSON Patch operation.
+type jsonPatchOp struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// JSONPatchTo returns an RFC 6902 JSON Patch transforming the JSON encoding of the container into
+// that of other. It consists of one replace operation per changed field, in schema order, with
+// values encoded as in the containers' JSON (the type as its enum symbol, podId as null or
+// {"string": ...}). Identical containers yield an empty patch ("[]").
+func (r *Container) JSONPatchTo(other *Container) ([]byte, error) {
+	if r == nil || other == nil {
+		return nil, errors.New("cannot compute a JSON patch involving a nil container")
+	}
+	values := other.GenericRecord()
+	ops := []jsonPatchOp{}
+	for i, name := range ContainerFieldNames {
+		if !r.fieldEqual(other, i) {
+			ops = append(ops, jsonPatchOp{Op: "replace", Path: "/" + name, Value: values[name]})
+		}
+	}
+	return json.Marshal(ops)
+}
@@ -0,0 +1,94 @@
+package sfgo
+
+import (
+	"fmt"
+	"io"
+)
+
+// PeekContainerID decodes only the id of the binary-encoded container at the beginning of b,
+// skipping over its remaining fields without decoding them, and returns the id along with the
+// size of the whole record, i.e., the offset of the next record in a packed buffer.
+func PeekContainerID(b []byte) (string, int, error) {
+	ends, err := scanContainerFields(b)
+	if err != nil {
+		return "", 0, newDecodeError(err)
+	}
+	id, _, err := peekString(b, 0)
+	if err != nil {
+		return "", 0, newDecodeError(err)
+	}
+	return id, ends[ContainerNumFields-1], nil
+}
+
+// scanContainerFields skips over the fields of the binary-encoded container at the beginning of
+// b without decoding them, and returns the offset following each field, by field index.
+func scanContainerFields(b []byte) ([ContainerNumFields]int, error) {
+	var ends [ContainerNumFields]int
+	n := 0
+	for i := range ends {
+		var err error
+		switch i {
+		case ContainerFieldType:
+			var m int
+			_, m, err = decodeLong(b[n:])
+			n += m
+		case ContainerFieldPrivileged:
+			if n >= len(b) {
+				err = io.ErrUnexpectedEOF
+			}
+			n++
+		case ContainerFieldPodID:
+			var branch int64
+			var m int
+			if branch, m, err = decodeLong(b[n:]); err != nil {
+				break
+			}
+			n += m
+			switch PodIdUnionTypeEnum(branch) {
+			case 0:
+			case PodIdUnionTypeEnumString:
+				n, err = skipString(b, n)
+			default:
+				err = fmt.Errorf("invalid podId union branch %d", branch)
+			}
+		default:
+			n, err = skipString(b, n)
+		}
+		if err != nil {
+			return ends, fmt.Errorf("field %s: %w", ContainerFieldNames[i], err)
+		}
+		ends[i] = n
+	}
+	return ends, nil
+}
+
+// peekString decodes the string at offset off of b and returns it with the offset following it.
+func peekString(b []byte, off int) (string, int, error) {
+	start, end, err := stringBounds(b, off)
+	if err != nil {
+		return "", 0, err
+	}
+	return string(b[start:end]), end, nil
+}
+
+// skipString returns the offset following the string at offset off of b.
+func skipString(b []byte, off int) (int, error) {
+	_, end, err := stringBounds(b, off)
+	return end, err
+}
+
+// stringBounds locates the bytes of the string at offset off of b.
+func stringBounds(b []byte, off int) (int, int, error) {
+	size, n, err := decodeLong(b[off:])
+	if err != nil {
+		return 0, 0, err
+	}
+	if size < 0 {
+		return 0, 0, fmt.Errorf("negative string length %d", size)
+	}
+	start := off + n
+	if size > int64(len(b)-start) {
+		return 0, 0, io.ErrUnexpectedEOF
+	}
+	return start, start + int(size), nil
+}
@@ -0,0 +1,96 @@
+package sfgo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ContainerStage is a transform applied to each container of a pipeline.
+type ContainerStage func(*AnnotatedContainer) error
+
+// Common pipeline stages.
+var (
+	// NormalizeImageStage lowercases the registry host of the image (see NormalizeImageRegistry).
+	NormalizeImageStage ContainerStage = func(c *AnnotatedContainer) error {
+		c.Image = NormalizeImageRegistry(c.Image)
+		return nil
+	}
+	// ImageRepoStage derives the image repository of containers that have none recorded
+	// (see DeriveImagerepo).
+	ImageRepoStage ContainerStage = func(c *AnnotatedContainer) error {
+		c.DeriveImagerepo()
+		return nil
+	}
+	// ValidateStage fails on invalid containers (see Validate).
+	ValidateStage ContainerStage = func(c *AnnotatedContainer) error {
+		return c.Validate()
+	}
+)
+
+// ImageRepoLabel is the label holding the image repository (see DeriveImagerepo).
+const ImageRepoLabel = "imagerepo"
+
+// ContainerPipeline applies ordered stages to batches of containers.
+type ContainerPipeline struct {
+	Stages []ContainerStage
+	// CollectErrors makes Apply run all stages on all containers, reporting every failure,
+	// instead of stopping at the first one. A container's remaining stages are skipped once
+	// one of them failed.
+	CollectErrors bool
+}
+
+// NewContainerPipeline creates a pipeline stopping on the first error.
+func NewContainerPipeline(stages ...ContainerStage) *ContainerPipeline {
+	return &ContainerPipeline{Stages: stages}
+}
+
+// ContainerStageError is the failure of a pipeline stage on a container.
+type ContainerStageError struct {
+	Index int // index of the container in the batch
+	Stage int // index of the stage in the pipeline
+	Err   error
+}
+
+func (e *ContainerStageError) Error() string {
+	return fmt.Sprintf("container %d, stage %d: %v", e.Index, e.Stage, e.Err)
+}
+
+func (e *ContainerStageError) Unwrap() error {
+	return e.Err
+}
+
+// ContainerPipelineError lists the stage failures collected by a pipeline.
+type ContainerPipelineError struct {
+	Errors []*ContainerStageError
+}
+
+func (e *ContainerPipelineError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d pipeline errors: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+// Apply runs the stages on each container in order, modifying them in place. It returns the
+// first *ContainerStageError, or a *ContainerPipelineError with all of them if the pipeline
+// collects errors. Decoded containers are wrapped with NewAnnotatedContainer to run stages.
+func (p *ContainerPipeline) Apply(cs []*AnnotatedContainer) error {
+	var errs []*ContainerStageError
+	for i, c := range cs {
+		for j, stage := range p.Stages {
+			if err := stage(c); err != nil {
+				serr := &ContainerStageError{Index: i, Stage: j, Err: err}
+				if !p.CollectErrors {
+					return serr
+				}
+				errs = append(errs, serr)
+				break
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return &ContainerPipelineError{Errors: errs}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package sfgo
+
+// FieldSource records where the value of a container field came from.
+type FieldSource struct {
+	Source     string
+	Confidence float64
+}
+
+// SetFieldSource records the source of the field with index i (see the ContainerField constants)
+// and the confidence in its value as in-memory metadata. It panics on out-of-range indices.
+func (a *AnnotatedContainer) SetFieldSource(i int, source string, confidence float64) {
+	checkFieldIndex(i)
+	if a.meta.sources == nil {
+		a.meta.sources = make(map[int]FieldSource)
+	}
+	a.meta.sources[i] = FieldSource{Source: source, Confidence: confidence}
+}
+
+// GetFieldSource returns the recorded source of the field with index i. It panics on
+// out-of-range indices.
+func (a *AnnotatedContainer) GetFieldSource(i int) (FieldSource, bool) {
+	checkFieldIndex(i)
+	s, ok := a.meta.sources[i]
+	return s, ok
+}
+
+// Merge merges the field values of other into the container, field by field, taking other's value
+// along with its source wherever other has the higher confidence. Fields without a recorded source
+// have confidence 0; on ties the container's own value is kept.
+func (a *AnnotatedContainer) Merge(other *AnnotatedContainer) {
+	for i := 0; i < ContainerNumFields; i++ {
+		theirs, ok := other.GetFieldSource(i)
+		if !ok {
+			continue
+		}
+		ours, _ := a.GetFieldSource(i)
+		if theirs.Confidence > ours.Confidence {
+			a.copyField(other.Container, i)
+			a.SetFieldSource(i, theirs.Source, theirs.Confidence)
+		}
+	}
+}
+
+// copyField copies the field with index i from other.
+func (r *Container) copyField(other *Container, i int) {
+	switch i {
+	case ContainerFieldID:
+		r.Id = other.Id
+	case ContainerFieldName:
+		r.Name = other.Name
+	case ContainerFieldImage:
+		r.Image = other.Image
+	case ContainerFieldImageID:
+		r.Imageid = other.Imageid
+	case ContainerFieldType:
+		r.Type = other.Type
+	case ContainerFieldPrivileged:
+		r.Privileged = other.Privileged
+	case ContainerFieldPodID:
+		r.PodId = nil
+		if other.PodId != nil {
+			p := *other.PodId
+			r.PodId = &p
+		}
+	}
+}
+
+// checkFieldIndex panics if i is not a container field index.
+func checkFieldIndex(i int) {
+	if i < 0 || i >= ContainerNumFields {
+		panic("Unknown field index")
+	}
+}
@@ -0,0 +1,86 @@
+package sfgo
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/actgardner/gogen-avro/v7/vm"
+)
+
+// ContainerOCFReader reads the containers of an Avro object container file, such as those written
+// by ContainerOCFWriter, tracking the stream offset so that decoding can be resumed. Unlike the
+// generated ContainerReader, it reuses the cached decoding program when the file was written with
+// the container schema, runs the registered enrichers, and reports errors with the package's
+// sentinel errors.
+type ContainerOCFReader struct {
+	s *ocfBlockStream
+	p *vm.Program
+}
+
+// NewContainerOCFReader reads the OCF header from r and returns a reader of its containers.
+func NewContainerOCFReader(r io.Reader) (*ContainerOCFReader, error) {
+	s, err := newOCFBlockStream(r)
+	if err != nil {
+		return nil, err
+	}
+	return newContainerOCFReader(s)
+}
+
+// NewContainerReaderAt creates a container reader over an OCF file that starts decoding at offset,
+// e.g., to resume checkpointed ingestion. The header is always read from the beginning of the file.
+// The offset must be a record boundary previously obtained from BytesConsumed while
+// AtBlockBoundary held, or 0 to start at the first block; other offsets yield decoding errors.
+func NewContainerReaderAt(r io.ReaderAt, offset int64) (*ContainerOCFReader, error) {
+	hs, err := newOCFBlockStream(io.NewSectionReader(r, 0, math.MaxInt64))
+	if err != nil {
+		return nil, err
+	}
+	if offset == 0 {
+		offset = hs.src.n
+	} else if offset < hs.src.n {
+		return nil, fmt.Errorf("offset %d is inside the OCF header", offset)
+	}
+	s := &ocfBlockStream{
+		src:    &countingReader{r: io.NewSectionReader(r, offset, math.MaxInt64-offset), n: offset},
+		header: hs.header,
+	}
+	return newContainerOCFReader(s)
+}
+
+func newContainerOCFReader(s *ocfBlockStream) (*ContainerOCFReader, error) {
+	p, err := getContainerProgramFor(s.header.schema)
+	if err != nil {
+		return nil, err
+	}
+	return &ContainerOCFReader{s: s, p: p}, nil
+}
+
+// Read returns the next container, or io.EOF at the end of the file.
+func (r *ContainerOCFReader) Read() (*Container, error) {
+	br, err := r.s.next()
+	if err != nil {
+		return nil, err
+	}
+	t := NewContainer()
+	if err := evalContainer(br, r.p, t); err != nil {
+		return nil, newDecodeError(err)
+	}
+	if err := enrichContainer(t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// BytesConsumed returns the number of bytes of the underlying OCF stream consumed so far,
+// including the header. Since blocks are read as a whole, the offset is a valid resume point
+// for NewContainerReaderAt only while AtBlockBoundary reports true.
+func (r *ContainerOCFReader) BytesConsumed() int64 {
+	return r.s.src.n
+}
+
+// AtBlockBoundary checks whether all records of the blocks read so far have been returned,
+// i.e., whether BytesConsumed is a record boundary at which decoding can be resumed.
+func (r *ContainerOCFReader) AtBlockBoundary() bool {
+	return r.s.remaining == 0
+}
@@ -0,0 +1,67 @@
+package sfgo
+
+import (
+	"encoding/json"
+	"sync"
+)
+
+var (
+	containerStringDefaults     map[int]string
+	containerStringDefaultsOnce sync.Once
+)
+
+// getContainerStringDefaults returns the declared defaults of the container's string fields, by field index.
+func getContainerStringDefaults() map[int]string {
+	containerStringDefaultsOnce.Do(func() {
+		containerStringDefaults = make(map[int]string)
+		var s struct {
+			Fields []struct {
+				Name    string           `json:"name"`
+				Type    interface{}      `json:"type"`
+				Default *json.RawMessage `json:"default"`
+			} `json:"fields"`
+		}
+		if err := json.Unmarshal([]byte(NewContainer().Schema()), &s); err != nil {
+			panic(err)
+		}
+		for i, f := range s.Fields {
+			var def string
+			if f.Type != "string" || f.Default == nil || json.Unmarshal(*f.Default, &def) != nil {
+				continue
+			}
+			containerStringDefaults[i] = def
+		}
+	})
+	return containerStringDefaults
+}
+
+// RepairDefaults sets empty string fields that declare a default in the schema to that default,
+// harmonizing legacy records that wrote "" instead, and reports whether anything changed. Fields
+// without declared default are left untouched. Note that the current SysFlow container schema
+// declares no defaults, in which case RepairDefaults is a no-op.
+func (r *Container) RepairDefaults() bool {
+	changed := false
+	for i, def := range getContainerStringDefaults() {
+		if def == "" {
+			continue
+		}
+		var field *string
+		switch i {
+		case ContainerFieldID:
+			field = &r.Id
+		case ContainerFieldName:
+			field = &r.Name
+		case ContainerFieldImage:
+			field = &r.Image
+		case ContainerFieldImageID:
+			field = &r.Imageid
+		default:
+			continue
+		}
+		if *field == "" {
+			*field = def
+			changed = true
+		}
+	}
+	return changed
+}
@@ -0,0 +1,59 @@
+package sfgo
+
+// runtimeNames maps container types to user-facing runtime names.
+var runtimeNames = map[ContainerType]string{
+	ContainerTypeCT_DOCKER:      "Docker",
+	ContainerTypeCT_LXC:         "LXC",
+	ContainerTypeCT_LIBVIRT_LXC: "libvirt LXC",
+	ContainerTypeCT_MESOS:       "Mesos",
+	ContainerTypeCT_RKT:         "rkt",
+	ContainerTypeCT_CUSTOM:      "Custom",
+	ContainerTypeCT_CRI:         "CRI",
+	ContainerTypeCT_CONTAINERD:  "containerd",
+	ContainerTypeCT_CRIO:        "CRI-O",
+	ContainerTypeCT_BPM:         "BPM",
+}
+
+// RuntimeName returns a user-facing name of the container runtime, e.g., "CRI-O" for CT_CRIO,
+// or "Unknown" for values outside the enumeration.
+func (e ContainerType) RuntimeName() string {
+	if name, ok := runtimeNames[e]; ok {
+		return name
+	}
+	return "Unknown"
+}
+
+// displayHints maps container types to UI color and icon hints.
+var displayHints = map[ContainerType][2]string{
+	ContainerTypeCT_DOCKER:      {"blue", "whale"},
+	ContainerTypeCT_LXC:         {"orange", "box"},
+	ContainerTypeCT_LIBVIRT_LXC: {"darkorange", "server"},
+	ContainerTypeCT_MESOS:       {"teal", "grid"},
+	ContainerTypeCT_RKT:         {"purple", "rocket"},
+	ContainerTypeCT_CUSTOM:      {"slategray", "puzzle"},
+	ContainerTypeCT_CRI:         {"navy", "plug"},
+	ContainerTypeCT_CONTAINERD:  {"cyan", "cube"},
+	ContainerTypeCT_CRIO:        {"red", "ship"},
+	ContainerTypeCT_BPM:         {"green", "gear"},
+}
+
+// DisplayHint returns a stable CSS color name and icon name for presenting the container runtime,
+// e.g., "blue" and "whale" for CT_DOCKER, or the neutral "gray" and "question" for values outside
+// the enumeration. The hints are part of the API and do not change between releases:
+//
+//	CT_DOCKER       blue        whale
+//	CT_LXC          orange      box
+//	CT_LIBVIRT_LXC  darkorange  server
+//	CT_MESOS        teal        grid
+//	CT_RKT          purple      rocket
+//	CT_CUSTOM       slategray   puzzle
+//	CT_CRI          navy        plug
+//	CT_CONTAINERD   cyan        cube
+//	CT_CRIO         red         ship
+//	CT_BPM          green       gear
+func (e ContainerType) DisplayHint() (color string, icon string) {
+	if h, ok := displayHints[e]; ok {
+		return h[0], h[1]
+	}
+	return "gray", "question"
+}
@@ -0,0 +1,47 @@
+package sfgo
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/actgardner/gogen-avro/v7/compiler"
+	"github.com/actgardner/gogen-avro/v7/schema/canonical"
+	"github.com/actgardner/gogen-avro/v7/vm"
+)
+
+// containerSchemaMatches caches, by writer schema, whether it is equivalent to the container schema.
+var containerSchemaMatches sync.Map
+
+// isContainerSchema checks whether the writer schema has the same parsing canonical form as the
+// container schema, i.e., the same CRC-64-AVRO fingerprint, in which case records can be decoded
+// without a resolving program. The result is cached, so only the first check of a schema parses it.
+func isContainerSchema(writer string) bool {
+	if writer == NewContainer().Schema() {
+		return true
+	}
+	if v, ok := containerSchemaMatches.Load(writer); ok {
+		return v.(bool)
+	}
+	match := false
+	if rec, err := parseContainerWriterSchema(writer); err == nil {
+		if cf, err := json.Marshal(canonical.DefinitionCanonicalForm(rec)); err == nil {
+			match = string(canonical.AvroCRC64Fingerprint(cf)) == ContainerAvroCRC64Fingerprint
+		}
+	}
+	containerSchemaMatches.Store(writer, match)
+	return match
+}
+
+// getContainerProgramFor returns a program decoding containers written with the writer schema:
+// the cached non-resolving program if the schemas match (see isContainerSchema), or a newly
+// compiled resolving program otherwise.
+func getContainerProgramFor(writer []byte) (*vm.Program, error) {
+	if isContainerSchema(string(writer)) {
+		return getContainerProgram()
+	}
+	prog, err := compiler.CompileSchemaBytes(writer, []byte(NewContainer().Schema()))
+	if err != nil {
+		return nil, newCompileError(err)
+	}
+	return prog, nil
+}
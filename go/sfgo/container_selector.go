@@ -0,0 +1,71 @@
+package sfgo
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// maxLabelValueLen is the Kubernetes limit on label value lengths.
+const maxLabelValueLen = 63
+
+var (
+	labelKeyRe   = regexp.MustCompile(`^(?:[a-z0-9](?:[-a-z0-9]*[a-z0-9])?(?:\.[a-z0-9](?:[-a-z0-9]*[a-z0-9])?)*/)?[A-Za-z0-9](?:[-A-Za-z0-9_.]*[A-Za-z0-9])?$`)
+	labelValueRe = regexp.MustCompile(`^(?:[A-Za-z0-9](?:[-A-Za-z0-9_.]*[A-Za-z0-9])?)?$`)
+)
+
+// MatchesSelector checks whether the container's in-memory labels match a Kubernetes-style
+// equality-based selector, e.g. "app=web,tier!=db". Requirements are comma-separated and
+// ANDed; each is one of key=value, key==value, key!=value, key (exists) or !key (absent).
+// As in Kubernetes, key!=value also matches containers without the key. An empty selector
+// matches every container.
+func (a *AnnotatedContainer) MatchesSelector(sel string) (bool, error) {
+	if strings.TrimSpace(sel) == "" {
+		return true, nil
+	}
+	match := true
+	for _, req := range strings.Split(sel, ",") {
+		ok, err := a.matchesRequirement(strings.TrimSpace(req))
+		if err != nil {
+			return false, fmt.Errorf("invalid selector '%s': %v", sel, err)
+		}
+		match = match && ok
+	}
+	return match, nil
+}
+
+func (a *AnnotatedContainer) matchesRequirement(req string) (bool, error) {
+	var key, op, value string
+	switch {
+	case strings.Contains(req, "!="):
+		i := strings.Index(req, "!=")
+		key, op, value = req[:i], "!=", req[i+2:]
+	case strings.Contains(req, "=="):
+		i := strings.Index(req, "==")
+		key, op, value = req[:i], "=", req[i+2:]
+	case strings.Contains(req, "="):
+		i := strings.Index(req, "=")
+		key, op, value = req[:i], "=", req[i+1:]
+	case strings.HasPrefix(req, "!"):
+		key, op = req[1:], "!"
+	default:
+		key = req
+	}
+	key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+	if !labelKeyRe.MatchString(key) {
+		return false, fmt.Errorf("bad label key '%s'", key)
+	}
+	if !labelValueRe.MatchString(value) || len(value) > maxLabelValueLen {
+		return false, fmt.Errorf("bad label value '%s'", value)
+	}
+	v, ok := a.Label(key)
+	switch op {
+	case "=":
+		return ok && v == value, nil
+	case "!=":
+		return !ok || v != value, nil
+	case "!":
+		return !ok, nil
+	}
+	return ok, nil
+}
@@ -0,0 +1,43 @@
+//go:build go1.23
+
+package sfgo
+
+import (
+	"bufio"
+	"io"
+	"iter"
+)
+
+// ContainersSeq returns an iterator over a stream of concatenated binary-encoded containers,
+// for use as in `for c, err := range ContainersSeq(r)`. Iteration ends at the end of the stream or
+// after yielding the first error (with a nil container). The compiled decoding program is shared
+// across iterators; each yielded container is freshly allocated, enriched by the registered
+// enrichers (see RegisterContainerEnricher), and may be retained by the caller.
+// Since r is read through a buffer, its position is unspecified once iteration stops, including
+// when the loop breaks early.
+func ContainersSeq(r io.Reader) iter.Seq2[*Container, error] {
+	return func(yield func(*Container, error) bool) {
+		prog, err := getContainerProgram()
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		br := bufio.NewReader(r)
+		for {
+			t := NewContainer()
+			if err := evalContainer(br, prog, t); err == io.EOF {
+				return
+			} else if err != nil {
+				yield(nil, newDecodeError(err))
+				return
+			}
+			if err := enrichContainer(t); err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(t, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,31 @@
+//go:build go1.23
+
+package sfgo
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestContainersSeqEnrich(t *testing.T) {
+	withTestEnricher(t, enrichName)
+	var buf bytes.Buffer
+	for i := 0; i < 2; i++ {
+		if err := decodeTestContainer().Serialize(&buf); err != nil {
+			t.Fatal(err)
+		}
+	}
+	n := 0
+	for c, err := range ContainersSeq(&buf) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.Name != "enriched" {
+			t.Errorf("container %d not enriched: %+v", n, c)
+		}
+		n++
+	}
+	if n != 2 {
+		t.Errorf("iterated over %d containers, want 2", n)
+	}
+}
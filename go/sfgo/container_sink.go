@@ -0,0 +1,139 @@
+package sfgo
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+// ContainerSink consumes a stream of containers, e.g., to write them in some format. It is
+// implemented by ContainerOCFWriter, ContainerJSONLSink and ContainerCSVSink.
+//
+// Sinks do not own the writer they wrap: Close flushes buffered output and writes any trailer,
+// such as the OCF checksum footer, but never closes the underlying writer, which remains the
+// caller's responsibility. A closed sink rejects further containers; closing it again is a no-op.
+type ContainerSink interface {
+	Write(c *Container) error
+	Flush() error
+	Close() error
+}
+
+// ContainerSource produces a stream of containers, ending with io.EOF. It is implemented by
+// ContainerReader, ContainerOCFReader, SyncContainerReader and ContainerMergeReader.
+type ContainerSource interface {
+	Read() (*Container, error)
+}
+
+// CopyContainers writes the containers read from src to dst until src is exhausted, and returns
+// the number of containers copied. It flushes dst but does not close it, so that several sources
+// can be copied to one sink.
+func CopyContainers(dst ContainerSink, src ContainerSource) (int, error) {
+	n := 0
+	for {
+		c, err := src.Read()
+		if err == io.EOF {
+			return n, dst.Flush()
+		} else if err != nil {
+			return n, err
+		}
+		if err := dst.Write(c); err != nil {
+			return n, err
+		}
+		n++
+	}
+}
+
+// Write implements ContainerSink (see WriteRecord).
+func (cw *ContainerOCFWriter) Write(c *Container) error {
+	return cw.WriteRecord(c)
+}
+
+// errSinkClosed is returned when writing to a closed sink.
+var errSinkClosed = errors.New("container sink already closed")
+
+// ContainerJSONLSink writes containers as newline-delimited JSON objects (see WriteContainersJSONL).
+type ContainerJSONLSink struct {
+	enc    *json.Encoder
+	closed bool
+}
+
+// NewContainerJSONLSink creates a JSONL sink writing to w. Each container is written immediately,
+// so Flush has nothing to do unless w buffers itself.
+func NewContainerJSONLSink(w io.Writer) *ContainerJSONLSink {
+	return &ContainerJSONLSink{enc: json.NewEncoder(w)}
+}
+
+// Write writes a container as a line of JSON.
+func (s *ContainerJSONLSink) Write(c *Container) error {
+	if s.closed {
+		return errSinkClosed
+	}
+	return s.enc.Encode(c)
+}
+
+// Flush is a no-op.
+func (s *ContainerJSONLSink) Flush() error {
+	return nil
+}
+
+// Close closes the sink.
+func (s *ContainerJSONLSink) Close() error {
+	s.closed = true
+	return nil
+}
+
+// ContainerCSVSink writes containers as CSV (see WriteContainersCSV). The header row is written
+// before the first container, or on Flush or Close if there is none.
+type ContainerCSVSink struct {
+	w      *csv.Writer
+	row    []string
+	header bool
+	closed bool
+}
+
+// NewContainerCSVSink creates a CSV sink writing to w.
+func NewContainerCSVSink(w io.Writer) *ContainerCSVSink {
+	return &ContainerCSVSink{w: csv.NewWriter(w), row: make([]string, ContainerNumFields)}
+}
+
+// Write writes a container as a CSV row.
+func (s *ContainerCSVSink) Write(c *Container) error {
+	if s.closed {
+		return errSinkClosed
+	}
+	if err := s.writeHeader(); err != nil {
+		return err
+	}
+	for i := range s.row {
+		s.row[i] = c.fieldString(i)
+	}
+	return s.w.Write(s.row)
+}
+
+// writeHeader writes the header row if not done yet.
+func (s *ContainerCSVSink) writeHeader() error {
+	if s.header {
+		return nil
+	}
+	s.header = true
+	return s.w.Write(ContainerFieldNames[:])
+}
+
+// Flush writes buffered rows to the underlying writer.
+func (s *ContainerCSVSink) Flush() error {
+	if err := s.writeHeader(); err != nil {
+		return err
+	}
+	s.w.Flush()
+	return s.w.Error()
+}
+
+// Close flushes and closes the sink.
+func (s *ContainerCSVSink) Close() error {
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	return s.Flush()
+}
@@ -0,0 +1,48 @@
+package sfgo
+
+// EncodedSize returns the size of the container's binary Avro encoding.
+func (r *Container) EncodedSize() int {
+	n := 0
+	for i := 0; i < ContainerNumFields; i++ {
+		n += r.fieldSize(i)
+	}
+	return n
+}
+
+// FieldSizes returns the number of encoded bytes contributed by each field, keyed by Avro field
+// name (see ContainerFieldNames). The sizes sum up to EncodedSize.
+func (r *Container) FieldSizes() map[string]int {
+	m := make(map[string]int, ContainerNumFields)
+	for i, name := range ContainerFieldNames {
+		m[name] = r.fieldSize(i)
+	}
+	return m
+}
+
+// fieldSize returns the encoded size of the field with index i.
+func (r *Container) fieldSize(i int) int {
+	switch i {
+	case ContainerFieldID:
+		return stringSize(r.Id)
+	case ContainerFieldName:
+		return stringSize(r.Name)
+	case ContainerFieldImage:
+		return stringSize(r.Image)
+	case ContainerFieldImageID:
+		return stringSize(r.Imageid)
+	case ContainerFieldType:
+		return longSize(int64(r.Type))
+	case ContainerFieldPrivileged:
+		return 1
+	case ContainerFieldPodID:
+		if r.PodId == nil {
+			return longSize(0)
+		}
+		n := longSize(int64(r.PodId.UnionType))
+		if r.PodId.UnionType == PodIdUnionTypeEnumString {
+			n += stringSize(r.PodId.String)
+		}
+		return n
+	}
+	panic("Unknown field index")
+}
@@ -0,0 +1,140 @@
+package sfgo
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// maxSyncFrameSize bounds the size of a sync-framed record; longer frames are discarded.
+const maxSyncFrameSize = 1 << 20
+
+// SerializeWithSync writes the container followed by the 16-byte sync marker, producing a
+// self-synchronizing frame that can be read with SyncContainerReader.
+func (r *Container) SerializeWithSync(w io.Writer, sync [ocfSyncSize]byte) error {
+	if err := r.Serialize(w); err != nil {
+		return err
+	}
+	_, err := w.Write(sync[:])
+	return err
+}
+
+// SyncContainerReader reads containers written with SerializeWithSync.
+//
+// The reader treats each sync marker as the end of a frame and decodes the bytes between two
+// markers as exactly one container. A corrupted frame, i.e., one that does not decode or is not
+// fully consumed by its container, makes Read return an ErrDecode error; the frame is dropped
+// and the next call resumes with the frame following the next marker. Thus corruption loses only
+// the frames it touches, unless a marker itself is damaged, in which case the frames on both sides
+// of it are lost. Frames larger than 1 MiB are discarded as corrupted. A trailing frame without
+// marker yields an ErrTruncated error.
+//
+// Markers are not escaped: a record whose encoding happens to contain the 16 bytes of the marker
+// (e.g., in a string field) is split there on read and reported as corrupted, along with the
+// fragment following it. With a randomly generated marker this requires adversarial input, so
+// the marker should not be known to the producers of untrusted field values.
+type SyncContainerReader struct {
+	r       *bufio.Reader
+	sync    [ocfSyncSize]byte
+	frame   []byte
+	skipped int64
+	pos     int64
+	err     error
+}
+
+// NewSyncContainerReader creates a reader of containers framed with the sync marker.
+func NewSyncContainerReader(r io.Reader, sync [ocfSyncSize]byte) *SyncContainerReader {
+	return &SyncContainerReader{r: bufio.NewReader(r), sync: sync}
+}
+
+// Read returns the next container, or io.EOF at the end of the stream.
+func (s *SyncContainerReader) Read() (*Container, error) {
+	frame, err := s.nextFrame()
+	if err != nil {
+		return nil, err
+	}
+	c, n, err := DeserializeContainerBytes(frame)
+	if err != nil {
+		s.skipped += int64(len(frame))
+		return nil, newDecodeError(err)
+	}
+	if n != len(frame) {
+		s.skipped += int64(len(frame))
+		return nil, &Error{Kind: ErrDecode, Err: fmt.Errorf("%d unexpected bytes after record", len(frame)-n)}
+	}
+	if err := enrichContainer(c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Skipped returns the number of bytes dropped as part of corrupted frames so far, excluding markers.
+func (s *SyncContainerReader) Skipped() int64 {
+	return s.skipped
+}
+
+// nextFrame returns the bytes up to the next sync marker, which is consumed.
+func (s *SyncContainerReader) nextFrame() ([]byte, error) {
+	s.frame = s.frame[:0]
+	oversized := false
+	for {
+		b, err := s.r.ReadByte()
+		if err == nil {
+			s.pos++
+		}
+		if err == io.EOF {
+			if len(s.frame) == 0 && !oversized {
+				return nil, io.EOF
+			}
+			s.skipped += int64(len(s.frame))
+			s.frame = s.frame[:0]
+			return nil, &Error{Kind: ErrTruncated, Err: fmt.Errorf("frame without sync marker at end of stream")}
+		} else if err != nil {
+			s.err = err
+			return nil, err
+		}
+		s.frame = append(s.frame, b)
+		if n := len(s.frame) - ocfSyncSize; n >= 0 && b == s.sync[ocfSyncSize-1] && bytes.Equal(s.frame[n:], s.sync[:]) {
+			if oversized {
+				s.skipped += int64(n)
+				return nil, &Error{Kind: ErrDecode, Err: fmt.Errorf("frame exceeds %d bytes", maxSyncFrameSize)}
+			}
+			return s.frame[:n], nil
+		}
+		if len(s.frame) > maxSyncFrameSize+ocfSyncSize {
+			// Keep the tail, which may hold the beginning of the marker.
+			keep := ocfSyncSize - 1
+			s.skipped += int64(len(s.frame) - keep)
+			s.frame = append(s.frame[:0], s.frame[len(s.frame)-keep:]...)
+			oversized = true
+		}
+	}
+}
+
+// ContainerResult is the outcome of decoding one sync-framed record.
+type ContainerResult struct {
+	Container *Container
+	Offset    int64 // stream offset of the record's frame
+	Err       error
+}
+
+// TryDeserializeContainers decodes all containers of a stream written with SerializeWithSync,
+// returning one result per frame instead of stopping at the first error. Corrupted frames,
+// enrichment failures and a truncated trailing frame are recoverable and yield a result with Err
+// set (see SyncContainerReader); a read error of r is not, and ends the results with it.
+func TryDeserializeContainers(r io.Reader, sync [ocfSyncSize]byte) []ContainerResult {
+	s := NewSyncContainerReader(r, sync)
+	var res []ContainerResult
+	for {
+		off := s.pos
+		c, err := s.Read()
+		if err == io.EOF {
+			return res
+		}
+		res = append(res, ContainerResult{Container: c, Offset: off, Err: err})
+		if s.err != nil {
+			return res
+		}
+	}
+}
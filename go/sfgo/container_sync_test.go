@@ -0,0 +1,35 @@
+package sfgo
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestSyncContainerReaderMarkerInRecord(t *testing.T) {
+	var sync [ocfSyncSize]byte
+	copy(sync[:], "0123456789abcdef")
+	var buf bytes.Buffer
+	c := decodeTestContainer()
+	c.Name = "x" + string(sync[:]) + "y"
+	if err := c.SerializeWithSync(&buf, sync); err != nil {
+		t.Fatal(err)
+	}
+	c = decodeTestContainer()
+	if err := c.SerializeWithSync(&buf, sync); err != nil {
+		t.Fatal(err)
+	}
+	s := NewSyncContainerReader(&buf, sync)
+	for i := 0; i < 2; i++ {
+		if _, err := s.Read(); !errors.Is(err, ErrDecode) {
+			t.Fatalf("fragment %d: error = %v, want ErrDecode", i, err)
+		}
+	}
+	if got, err := s.Read(); err != nil || got.Id != c.Id {
+		t.Fatalf("next record: got %v, error %v", got, err)
+	}
+	if _, err := s.Read(); err != io.EOF {
+		t.Errorf("end: error = %v, want io.EOF", err)
+	}
+}
@@ -0,0 +1,72 @@
+package sfgo
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+
+	"github.com/actgardner/gogen-avro/v7/container"
+)
+
+const traceRecordsPerBlock = 1000
+
+var (
+	traceRegistries = []string{"", "docker.io/library/", "quay.io/", "gcr.io/", "registry.internal.example.com:5000/"}
+	traceRepos      = []string{"nginx", "redis", "postgres", "alpine", "busybox", "envoyproxy/envoy", "prometheus/node-exporter", "team/payments-api", "team/frontend"}
+	traceTags       = []string{"", ":latest", ":1.21", ":1.9.3", ":7-alpine", ":v2.10.0", ":stable"}
+	traceAdjectives = []string{"admiring", "brave", "eager", "focused", "jolly", "quirky", "serene", "vigilant"}
+	traceNouns      = []string{"turing", "hopper", "lovelace", "curie", "knuth", "ritchie", "torvalds", "wozniak"}
+	// traceTypes is weighted towards the runtimes most commonly observed in practice.
+	traceTypes = []ContainerType{
+		ContainerTypeCT_DOCKER, ContainerTypeCT_DOCKER, ContainerTypeCT_DOCKER,
+		ContainerTypeCT_CONTAINERD, ContainerTypeCT_CONTAINERD, ContainerTypeCT_CRIO,
+		ContainerTypeCT_CRI, ContainerTypeCT_LXC, ContainerTypeCT_LIBVIRT_LXC,
+		ContainerTypeCT_MESOS, ContainerTypeCT_RKT, ContainerTypeCT_CUSTOM, ContainerTypeCT_BPM,
+	}
+)
+
+// GenerateContainerTrace writes n synthetic containers to an OCF file compressed with codec
+// (null, deflate or snappy). The output is fully determined by seed.
+func GenerateContainerTrace(w io.Writer, n int, seed int64, codec string) error {
+	switch c := container.Codec(codec); c {
+	case container.Null, container.Deflate, container.Snappy:
+	default:
+		return &Error{Kind: ErrUnsupportedOp, Err: fmt.Errorf("unsupported OCF codec '%s'", codec)}
+	}
+	cw, err := NewContainerWriter(w, container.Codec(codec), traceRecordsPerBlock)
+	if err != nil {
+		return err
+	}
+	rnd := rand.New(rand.NewSource(seed))
+	for i := 0; i < n; i++ {
+		if err := cw.WriteRecord(randomContainer(rnd)); err != nil {
+			return err
+		}
+	}
+	return cw.Flush()
+}
+
+func randomContainer(rnd *rand.Rand) *Container {
+	c := &Container{
+		Id:         randomHex(rnd, 12),
+		Name:       traceAdjectives[rnd.Intn(len(traceAdjectives))] + "_" + traceNouns[rnd.Intn(len(traceNouns))],
+		Image:      traceRegistries[rnd.Intn(len(traceRegistries))] + traceRepos[rnd.Intn(len(traceRepos))] + traceTags[rnd.Intn(len(traceTags))],
+		Imageid:    randomHex(rnd, 64),
+		Type:       traceTypes[rnd.Intn(len(traceTypes))],
+		Privileged: rnd.Intn(10) == 0,
+	}
+	switch c.Type {
+	case ContainerTypeCT_CRI, ContainerTypeCT_CONTAINERD, ContainerTypeCT_CRIO:
+		c.PodId = &PodIdUnion{String: randomHex(rnd, 12), UnionType: PodIdUnionTypeEnumString}
+	}
+	return c
+}
+
+func randomHex(rnd *rand.Rand, n int) string {
+	const digits = "0123456789abcdef"
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = digits[rnd.Intn(len(digits))]
+	}
+	return string(b)
+}
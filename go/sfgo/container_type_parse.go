@@ -0,0 +1,22 @@
+package sfgo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseContainerTypeLenient parses a container type case-insensitively, accepting the enum
+// symbols with or without their "CT_" prefix (e.g., "ct_docker", "crio"), Falco's runtime
+// strings (e.g., "cri-o", "libvirt-lxc") and the names returned by RuntimeName. Unknown values
+// are rejected; use NewContainerTypeValue for strict parsing of enum symbols.
+func ParseContainerTypeLenient(raw string) (ContainerType, error) {
+	s := strings.TrimSpace(raw)
+	for t := ContainerTypeCT_DOCKER; t <= ContainerTypeCT_BPM; t++ {
+		sym := t.String()
+		if strings.EqualFold(s, sym) || strings.EqualFold(s, strings.TrimPrefix(sym, "CT_")) ||
+			strings.EqualFold(s, t.FalcoType()) || strings.EqualFold(s, t.RuntimeName()) {
+			return t, nil
+		}
+	}
+	return -1, fmt.Errorf("invalid value for ContainerType: '%s'", raw)
+}
@@ -0,0 +1,125 @@
+package sfgo
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ContainerValidationError lists all problems found while validating a container.
+type ContainerValidationError struct {
+	Problems []string
+}
+
+func (e *ContainerValidationError) Error() string {
+	return "invalid container: " + strings.Join(e.Problems, "; ")
+}
+
+// containerValidator is a registered validation rule.
+type containerValidator struct {
+	name string
+	fn   func(*Container) error
+}
+
+var (
+	validatorsMu sync.RWMutex
+	validators   []containerValidator
+)
+
+// RegisterContainerValidator registers a deployment-specific validation rule, such as a list of
+// allowed registries, that Validate runs after the built-in checks. Registering a name again
+// replaces the rule. Validators must be safe for concurrent use, and should be registered during
+// initialization.
+func RegisterContainerValidator(name string, fn func(*Container) error) {
+	validatorsMu.Lock()
+	defer validatorsMu.Unlock()
+	vs := append([]containerValidator(nil), validators...)
+	for i, v := range vs {
+		if v.name == name {
+			vs[i].fn = fn
+			validators = vs
+			return
+		}
+	}
+	validators = append(vs, containerValidator{name: name, fn: fn})
+}
+
+// Validate checks that the container has the required attributes and well-formed enum and union
+// values, and runs all registered validators (see RegisterContainerValidator). Validator failures
+// are listed with the validator's name.
+func (r *Container) Validate() error {
+	validatorsMu.RLock()
+	vs := validators
+	validatorsMu.RUnlock()
+	return r.validate(vs)
+}
+
+// ValidateWith is Validate running only the named validators, in the given order; an empty list
+// runs the built-in checks alone. Unknown names are reported as problems.
+func (r *Container) ValidateWith(names ...string) error {
+	validatorsMu.RLock()
+	all := validators
+	validatorsMu.RUnlock()
+	vs := make([]containerValidator, 0, len(names))
+	var unknown []string
+outer:
+	for _, name := range names {
+		for _, v := range all {
+			if v.name == name {
+				vs = append(vs, v)
+				continue outer
+			}
+		}
+		unknown = append(unknown, fmt.Sprintf("unknown validator '%s'", name))
+	}
+	err := r.validate(vs)
+	if len(unknown) == 0 {
+		return err
+	}
+	if err == nil {
+		return &ContainerValidationError{Problems: unknown}
+	}
+	verr := err.(*ContainerValidationError)
+	verr.Problems = append(verr.Problems, unknown...)
+	return verr
+}
+
+// validate runs the built-in checks and the validators.
+func (r *Container) validate(vs []containerValidator) error {
+	var problems []string
+	if r.Id == "" {
+		problems = append(problems, "missing id")
+	}
+	if r.Image == "" {
+		problems = append(problems, "missing image")
+	}
+	if r.Type < ContainerTypeCT_DOCKER || r.Type > ContainerTypeCT_BPM {
+		problems = append(problems, fmt.Sprintf("invalid type %d", r.Type))
+	}
+	if r.PodId != nil && r.PodId.UnionType != PodIdUnionTypeEnumString {
+		problems = append(problems, fmt.Sprintf("invalid podId union type %d", r.PodId.UnionType))
+	}
+	for _, v := range vs {
+		if err := v.fn(r); err != nil {
+			problems = append(problems, fmt.Sprintf("validator '%s': %v", v.name, err))
+		}
+	}
+	if len(problems) > 0 {
+		return &ContainerValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// PartitionContainers validates each container and separates the valid ones from the invalid
+// ones, preserving their order. errs[i] is the validation error of invalid[i].
+func PartitionContainers(cs []*Container) (valid, invalid []*Container, errs []error) {
+	for _, c := range cs {
+		if err := c.Validate(); err != nil {
+			invalid = append(invalid, c)
+			errs = append(errs, err)
+		} else {
+			valid = append(valid, c)
+		}
+	}
+	return valid, invalid, errs
+}
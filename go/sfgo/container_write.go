@@ -0,0 +1,97 @@
+package sfgo
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WriteContainersOCF writes the containers to w as a deflate-compressed OCF file.
+func WriteContainersOCF(w io.Writer, cs []*Container) error {
+	cw, err := NewContainerOCFWriter(w, "deflate", 0)
+	if err != nil {
+		return err
+	}
+	return writeContainersSink(cw, cs)
+}
+
+// WriteContainersJSONL writes the containers to w as newline-delimited JSON objects.
+func WriteContainersJSONL(w io.Writer, cs []*Container) error {
+	return writeContainersSink(NewContainerJSONLSink(w), cs)
+}
+
+// TranscodeContainerOCFToJSONL converts a container OCF stream to newline-delimited JSON, as
+// written by WriteContainersJSONL, in record order. Records are streamed one at a time, so memory
+// use is bounded by the size of a block regardless of the size of the file.
+func TranscodeContainerOCFToJSONL(r io.Reader, w io.Writer) error {
+	cr, err := NewContainerOCFReader(r)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	for {
+		c, err := cr.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			// Keep the records converted so far.
+			bw.Flush()
+			return newDecodeError(err)
+		}
+		if err := enc.Encode(c); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// WriteContainersCSV writes the containers to w as CSV, with a header row of Avro field names
+// (see ContainerFieldNames) and values rendered as by FlatMap.
+func WriteContainersCSV(w io.Writer, cs []*Container) error {
+	return writeContainersSink(NewContainerCSVSink(w), cs)
+}
+
+// writeContainersSink writes the containers to the sink and closes it.
+func writeContainersSink(s ContainerSink, cs []*Container) error {
+	for _, c := range cs {
+		if err := s.Write(c); err != nil {
+			return err
+		}
+	}
+	return s.Close()
+}
+
+// WriteContainersFile writes the containers to the file at path, choosing the format from the
+// file extension: .avro (OCF), .jsonl (newline-delimited JSON) or .csv.
+func WriteContainersFile(path string, cs []*Container) error {
+	var write func(io.Writer, []*Container) error
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".avro":
+		write = WriteContainersOCF
+	case ".jsonl":
+		write = WriteContainersJSONL
+	case ".csv":
+		write = WriteContainersCSV
+	default:
+		return fmt.Errorf("unsupported container file extension '%s'", ext)
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(f)
+	if err := write(bw, cs); err != nil {
+		f.Close()
+		return err
+	}
+	if err := bw.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
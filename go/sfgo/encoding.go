@@ -0,0 +1,56 @@
+package sfgo
+
+import (
+	"errors"
+	"io"
+)
+
+// decodeLong decodes a zig-zag varint long from the beginning of b and returns the number of bytes read.
+func decodeLong(b []byte) (int64, int, error) {
+	var v uint64
+	for i, shift := 0, uint(0); i < len(b) && shift < 64; i, shift = i+1, shift+7 {
+		v |= uint64(b[i]&127) << shift
+		if b[i]&128 == 0 {
+			return int64(v>>1) ^ -int64(v&1), i + 1, nil
+		}
+	}
+	if len(b) < 10 {
+		return 0, 0, io.ErrUnexpectedEOF
+	}
+	return 0, 0, errors.New("varint overflows a 64-bit integer")
+}
+
+// readLong reads a zig-zag varint long from r.
+// It returns io.EOF only if no byte could be read.
+func readLong(r io.ByteReader) (int64, error) {
+	var v uint64
+	for shift := uint(0); shift < 64; shift += 7 {
+		b, err := r.ReadByte()
+		if err == io.EOF && shift > 0 {
+			return 0, io.ErrUnexpectedEOF
+		} else if err != nil {
+			return 0, err
+		}
+		v |= uint64(b&127) << shift
+		if b&128 == 0 {
+			return int64(v>>1) ^ -int64(v&1), nil
+		}
+	}
+	return 0, errors.New("varint overflows a 64-bit integer")
+}
+
+// longSize returns the size of the zig-zag varint encoding of v.
+func longSize(v int64) int {
+	u := uint64(v<<1) ^ uint64(v>>63)
+	n := 1
+	for u >= 0x80 {
+		u >>= 7
+		n++
+	}
+	return n
+}
+
+// stringSize returns the size of the Avro encoding of s.
+func stringSize(s string) int {
+	return longSize(int64(len(s))) + len(s)
+}
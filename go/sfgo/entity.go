@@ -0,0 +1,59 @@
+package sfgo
+
+import (
+	"io"
+	"sync"
+
+	"github.com/actgardner/gogen-avro/v7/compiler"
+	"github.com/actgardner/gogen-avro/v7/vm"
+	"github.com/actgardner/gogen-avro/v7/vm/types"
+)
+
+// Entity is implemented by all SysFlow records generated in this package.
+type Entity interface {
+	types.Field
+	Serialize(w io.Writer) error
+	Schema() string
+	SchemaName() string
+}
+
+// entityProgs caches compiled (non-resolving) decoding programs by schema name.
+var entityProgs sync.Map
+
+// getEntityProgram returns the compiled decoding program for t's schema.
+func getEntityProgram(t Entity) (*vm.Program, error) {
+	if p, ok := entityProgs.Load(t.SchemaName()); ok {
+		return p.(*vm.Program), nil
+	}
+	p, err := compiler.CompileSchemaBytes([]byte(t.Schema()), []byte(t.Schema()))
+	if err != nil {
+		return nil, newCompileError(err)
+	}
+	entityProgs.Store(t.SchemaName(), p)
+	return p, nil
+}
+
+// DeserializeEntity decodes a record written with t's schema from r into t.
+// Fields of any Avro type, including bytes and fixed, are decoded through the field
+// wrappers returned by t.Get, so the record's own Set* methods are never invoked.
+func DeserializeEntity(r io.Reader, t Entity) error {
+	prog, err := getEntityProgram(t)
+	if err != nil {
+		return err
+	}
+	return evalEntity(r, prog, t)
+}
+
+// evalEntity runs a decoding program over r, wrapping failures with the package's sentinel errors.
+// A clean end of input before any byte is read is returned as io.EOF.
+func evalEntity(r io.Reader, prog *vm.Program, t types.Field) error {
+	cr := &countingReader{r: r}
+	err := vm.Eval(cr, prog, t)
+	if err == nil {
+		return nil
+	}
+	if cr.n == 0 && err == io.EOF {
+		return io.EOF
+	}
+	return newDecodeError(err)
+}
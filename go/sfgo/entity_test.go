@@ -0,0 +1,81 @@
+package sfgo
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func testFOID(seed byte) FOID {
+	var oid FOID
+	for i := range oid {
+		oid[i] = seed + byte(i)
+	}
+	return oid
+}
+
+func TestDeserializeEntityFixed(t *testing.T) {
+	f := NewFile()
+	f.State = SFObjectStateMODIFIED
+	f.Oid = testFOID(0xa0)
+	f.Ts = 1634000000
+	f.Path = "/etc/passwd"
+	f.ContainerId = &ContainerIdUnion{UnionType: ContainerIdUnionTypeEnumString, String: "2f3b"}
+	var buf bytes.Buffer
+	if err := f.Serialize(&buf); err != nil {
+		t.Fatal(err)
+	}
+	d := NewFile()
+	if err := DeserializeEntity(bytes.NewReader(buf.Bytes()), d); err != nil {
+		t.Fatal(err)
+	}
+	if d.Oid != f.Oid || d.State != f.State || d.Ts != f.Ts || d.Path != f.Path || d.ContainerId.String != f.ContainerId.String {
+		t.Errorf("decoded %+v, want %+v", d, f)
+	}
+	if err := DeserializeEntity(bytes.NewReader(buf.Bytes()[:10]), NewFile()); !errors.Is(err, ErrTruncated) {
+		t.Errorf("truncated fixed field: error = %v, want ErrTruncated", err)
+	}
+}
+
+func TestDeserializeEntityFixedInUnion(t *testing.T) {
+	e := NewFileEvent()
+	e.ProcOID = &OID{Hpid: 42, CreateTS: 1634000000}
+	e.FileOID = testFOID(0x10)
+	e.NewFileOID = &NewFileOIDUnion{UnionType: NewFileOIDUnionTypeEnumFOID, FOID: testFOID(0x40)}
+	var buf bytes.Buffer
+	if err := e.Serialize(&buf); err != nil {
+		t.Fatal(err)
+	}
+	d := NewFileEvent()
+	if err := DeserializeEntity(bytes.NewReader(buf.Bytes()), d); err != nil {
+		t.Fatal(err)
+	}
+	if d.FileOID != e.FileOID || d.NewFileOID == nil || d.NewFileOID.FOID != e.NewFileOID.FOID || *d.ProcOID != *e.ProcOID {
+		t.Errorf("decoded %+v, want %+v", d, e)
+	}
+}
+
+func TestDeserializeSysFlowCached(t *testing.T) {
+	sf := NewSysFlow()
+	sf.Rec = &RecUnion{UnionType: RecUnionTypeEnumContainer, Container: decodeTestContainer()}
+	var buf bytes.Buffer
+	for i := 0; i < 2; i++ {
+		if err := sf.Serialize(&buf); err != nil {
+			t.Fatal(err)
+		}
+	}
+	r := bytes.NewReader(buf.Bytes())
+	for i := 0; i < 2; i++ {
+		d, err := DeserializeSysFlowCached(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.Rec.UnionType != RecUnionTypeEnumContainer || !d.Rec.Container.Equal(sf.Rec.Container) {
+			t.Errorf("record %d: decoded %+v, want %+v", i, d.Rec, sf.Rec)
+		}
+	}
+	if _, err := DeserializeSysFlowCached(r); err != io.EOF {
+		t.Errorf("at end of input: error = %v, want io.EOF", err)
+	}
+}
@@ -0,0 +1,94 @@
+package sfgo
+
+import (
+	"errors"
+	"io"
+	"strings"
+)
+
+// Sentinel errors returned (wrapped) by the hand-written encoding APIs.
+// ErrTruncated, ErrUnsupportedOp, ErrCRCMismatch, ErrLimitExceeded and ErrFieldDefaulted are
+// specializations of ErrDecode. The generated functions, such as DeserializeContainer, return the
+// underlying compiler and VM errors unwrapped; DeserializeContainerCached and
+// DeserializeContainerFromSchemaCached are their counterparts returning wrapped errors.
+var (
+	ErrSchemaCompile  = errors.New("schema compilation failed")
+	ErrDecode         = errors.New("decoding failed")
+	ErrUnsupportedOp  = errors.New("unsupported operation")
+	ErrTruncated      = errors.New("truncated input")
+	ErrCRCMismatch    = errors.New("checksum mismatch")
+	ErrRoundTrip      = errors.New("serialized record does not round-trip")
+	ErrLimitExceeded  = errors.New("decode limit exceeded")
+	ErrFieldDefaulted = errors.New("field defaulted")
+	ErrOutOfRange     = errors.New("index out of range")
+)
+
+// Error wraps an underlying failure with one of the package's sentinel errors.
+type Error struct {
+	Kind error
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return e.Kind.Error() + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the error is of the target kind, taking the kind hierarchy into account.
+func (e *Error) Is(target error) bool {
+	if target == e.Kind {
+		return true
+	}
+	return target == ErrDecode && (e.Kind == ErrTruncated || e.Kind == ErrUnsupportedOp || e.Kind == ErrCRCMismatch || e.Kind == ErrLimitExceeded || e.Kind == ErrFieldDefaulted)
+}
+
+// newCompileError wraps a schema compilation error.
+func newCompileError(err error) error {
+	return &Error{Kind: ErrSchemaCompile, Err: err}
+}
+
+// newDecodeError wraps a decoding error, classifying truncated inputs and unsupported operations.
+// The VM reports panics raised by generated setters as plain errors, hence the message check.
+func newDecodeError(err error) error {
+	var e *Error
+	switch {
+	case errors.As(err, &e):
+		return err
+	case errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF):
+		return &Error{Kind: ErrTruncated, Err: err}
+	case strings.Contains(err.Error(), "Unsupported operation"):
+		return &Error{Kind: ErrUnsupportedOp, Err: err}
+	}
+	return &Error{Kind: ErrDecode, Err: err}
+}
+
+// countingReader counts the bytes read from the underlying reader.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func (c *countingReader) ReadByte() (byte, error) {
+	if br, ok := c.r.(io.ByteReader); ok {
+		b, err := br.ReadByte()
+		if err == nil {
+			c.n++
+		}
+		return b, err
+	}
+	var b [1]byte
+	if _, err := io.ReadFull(c, b[:]); err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
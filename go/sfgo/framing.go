@@ -0,0 +1,121 @@
+package sfgo
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Marker sequences of the two supported single-record framings. The Avro single-object encoding
+// prefixes the record with SingleObjectMarker followed by the CRC-64-AVRO fingerprint of the
+// writer schema in little-endian byte order; the Confluent wire format prefixes it with
+// ConfluentMagic followed by the schema registry id in big-endian byte order.
+var (
+	SingleObjectMarker = [2]byte{0xc3, 0x01}
+	ConfluentMagic     = [1]byte{0x00}
+)
+
+// Header sizes of the framings.
+const (
+	singleObjectHeaderSize = len(SingleObjectMarker) + 8
+	confluentHeaderSize    = len(ConfluentMagic) + 4
+)
+
+// The framing helpers below are the only place where the byte order of either framing is
+// decided; the codecs further down must not encode fingerprints or schema ids themselves.
+
+// appendSingleObjectHeader appends the single-object header for the schema fingerprint to b.
+func appendSingleObjectHeader(b []byte, fingerprint uint64) []byte {
+	var h [singleObjectHeaderSize]byte
+	copy(h[:], SingleObjectMarker[:])
+	binary.LittleEndian.PutUint64(h[len(SingleObjectMarker):], fingerprint)
+	return append(b, h[:]...)
+}
+
+// parseSingleObjectHeader returns the schema fingerprint of a single-object encoded record and
+// the record's payload.
+func parseSingleObjectHeader(b []byte) (uint64, []byte, error) {
+	if len(b) < singleObjectHeaderSize || b[0] != SingleObjectMarker[0] || b[1] != SingleObjectMarker[1] {
+		return 0, nil, &Error{Kind: ErrDecode, Err: fmt.Errorf("missing single-object marker")}
+	}
+	return binary.LittleEndian.Uint64(b[len(SingleObjectMarker):]), b[singleObjectHeaderSize:], nil
+}
+
+// appendConfluentHeader appends the Confluent wire format header for the schema id to b.
+func appendConfluentHeader(b []byte, id uint32) []byte {
+	var h [confluentHeaderSize]byte
+	copy(h[:], ConfluentMagic[:])
+	binary.BigEndian.PutUint32(h[len(ConfluentMagic):], id)
+	return append(b, h[:]...)
+}
+
+// parseConfluentHeader returns the schema id of a record in Confluent wire format and the
+// record's payload.
+func parseConfluentHeader(b []byte) (uint32, []byte, error) {
+	if len(b) < confluentHeaderSize || b[0] != ConfluentMagic[0] {
+		return 0, nil, &Error{Kind: ErrDecode, Err: fmt.Errorf("missing Confluent magic byte")}
+	}
+	return binary.BigEndian.Uint32(b[len(ConfluentMagic):]), b[confluentHeaderSize:], nil
+}
+
+// ContainerFingerprint returns the CRC-64-AVRO fingerprint of the container schema. The generated
+// ContainerAvroCRC64Fingerprint holds it in little-endian byte order, i.e., as it appears in
+// single-object headers.
+func ContainerFingerprint() uint64 {
+	return binary.LittleEndian.Uint64([]byte(ContainerAvroCRC64Fingerprint))
+}
+
+// MarshalSingleObject encodes the container in the Avro single-object encoding, i.e., prefixed
+// with SingleObjectMarker and the fingerprint of the container schema.
+func (r *Container) MarshalSingleObject() ([]byte, error) {
+	b := make([]byte, 0, singleObjectHeaderSize+r.EncodedSize())
+	return r.AppendBinary(appendSingleObjectHeader(b, ContainerFingerprint()))
+}
+
+// DeserializeContainerSingleObject decodes a container in the Avro single-object encoding. It
+// returns an ErrDecode error if the record was written with another schema, as identified by its
+// fingerprint, or does not fill b.
+func DeserializeContainerSingleObject(b []byte) (*Container, error) {
+	fp, payload, err := parseSingleObjectHeader(b)
+	if err != nil {
+		return nil, err
+	}
+	if fp != ContainerFingerprint() {
+		return nil, &Error{Kind: ErrDecode, Err: fmt.Errorf("schema fingerprint %016x is not the container schema's", fp)}
+	}
+	return deserializeFramedContainer(payload)
+}
+
+// MarshalConfluent encodes the container in the Confluent wire format, i.e., prefixed with
+// ConfluentMagic and the id under which the container schema is registered.
+func (r *Container) MarshalConfluent(schemaID uint32) ([]byte, error) {
+	b := make([]byte, 0, confluentHeaderSize+r.EncodedSize())
+	return r.AppendBinary(appendConfluentHeader(b, schemaID))
+}
+
+// DeserializeContainerConfluent decodes a container in the Confluent wire format written with the
+// container schema, and returns it with the schema id found in the header, which callers resolving
+// schemas from a registry should check. It returns an ErrDecode error if the record does not fill b.
+func DeserializeContainerConfluent(b []byte) (*Container, uint32, error) {
+	id, payload, err := parseConfluentHeader(b)
+	if err != nil {
+		return nil, 0, err
+	}
+	c, err := deserializeFramedContainer(payload)
+	if err != nil {
+		return nil, 0, err
+	}
+	return c, id, nil
+}
+
+// deserializeFramedContainer decodes the payload of a framed record, which must be exactly one
+// container.
+func deserializeFramedContainer(payload []byte) (*Container, error) {
+	c, n, err := DeserializeContainerBytes(payload)
+	if err != nil {
+		return nil, newDecodeError(err)
+	}
+	if n != len(payload) {
+		return nil, &Error{Kind: ErrDecode, Err: fmt.Errorf("%d unexpected bytes after record", len(payload)-n)}
+	}
+	return c, nil
+}
@@ -0,0 +1,115 @@
+package sfgo
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestSingleObjectHeader(t *testing.T) {
+	got := appendSingleObjectHeader([]byte{0xff}, 0x0102030405060708)
+	want := []byte{0xff, 0xc3, 0x01, 0x08, 0x07, 0x06, 0x05, 0x04, 0x03, 0x02, 0x01}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("appendSingleObjectHeader = % x, want % x", got, want)
+	}
+	fp, payload, err := parseSingleObjectHeader(append(got[1:], 0xaa))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fp != 0x0102030405060708 || !bytes.Equal(payload, []byte{0xaa}) {
+		t.Errorf("parseSingleObjectHeader = %016x, % x", fp, payload)
+	}
+}
+
+func TestConfluentHeader(t *testing.T) {
+	got := appendConfluentHeader([]byte{0xff}, 0x01020304)
+	want := []byte{0xff, 0x00, 0x01, 0x02, 0x03, 0x04}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("appendConfluentHeader = % x, want % x", got, want)
+	}
+	id, payload, err := parseConfluentHeader(append(got[1:], 0xaa))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != 0x01020304 || !bytes.Equal(payload, []byte{0xaa}) {
+		t.Errorf("parseConfluentHeader = %08x, % x", id, payload)
+	}
+}
+
+func TestFramingHeaderErrors(t *testing.T) {
+	for _, b := range [][]byte{nil, {0xc3}, {0xc3, 0x01, 1, 2, 3, 4, 5, 6, 7}, {0xc3, 0x02, 1, 2, 3, 4, 5, 6, 7, 8}} {
+		if _, _, err := parseSingleObjectHeader(b); !errors.Is(err, ErrDecode) {
+			t.Errorf("parseSingleObjectHeader(% x) error = %v, want ErrDecode", b, err)
+		}
+	}
+	for _, b := range [][]byte{nil, {0x00, 1, 2, 3}, {0x01, 1, 2, 3, 4}} {
+		if _, _, err := parseConfluentHeader(b); !errors.Is(err, ErrDecode) {
+			t.Errorf("parseConfluentHeader(% x) error = %v, want ErrDecode", b, err)
+		}
+	}
+}
+
+func TestContainerFingerprint(t *testing.T) {
+	// The CRC-64-AVRO fingerprint of the container schema, as generated.
+	if got, want := ContainerFingerprint(), uint64(0xcdc8559b0cfc76ba); got != want {
+		t.Errorf("ContainerFingerprint = %016x, want %016x", got, want)
+	}
+}
+
+func framingTestContainer() *Container {
+	c := NewContainer()
+	c.Id = "abc"
+	c.Image = "nginx:1.21"
+	c.Type = ContainerTypeCT_CRIO
+	c.PodId = &PodIdUnion{UnionType: PodIdUnionTypeEnumString, String: "pod"}
+	return c
+}
+
+func TestSingleObjectRoundTrip(t *testing.T) {
+	c := framingTestContainer()
+	b, err := c.MarshalSingleObject()
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := []byte{0xc3, 0x01, 0xba, 0x76, 0xfc, 0x0c, 0x9b, 0x55, 0xc8, 0xcd}
+	if !bytes.HasPrefix(b, header) {
+		t.Fatalf("MarshalSingleObject header = % x, want % x", b[:len(header)], header)
+	}
+	d, err := DeserializeContainerSingleObject(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !d.Equal(c) {
+		t.Errorf("decoded %+v, want %+v", d, c)
+	}
+	other := append([]byte{}, b...)
+	other[2] ^= 1
+	if _, err := DeserializeContainerSingleObject(other); !errors.Is(err, ErrDecode) {
+		t.Errorf("foreign fingerprint: error = %v, want ErrDecode", err)
+	}
+	if _, err := DeserializeContainerSingleObject(append(b, 0)); !errors.Is(err, ErrDecode) {
+		t.Errorf("trailing bytes: error = %v, want ErrDecode", err)
+	}
+}
+
+func TestConfluentRoundTrip(t *testing.T) {
+	c := framingTestContainer()
+	b, err := c.MarshalConfluent(258)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := []byte{0x00, 0x00, 0x00, 0x01, 0x02}
+	if !bytes.HasPrefix(b, header) {
+		t.Fatalf("MarshalConfluent header = % x, want % x", b[:len(header)], header)
+	}
+	d, id, err := DeserializeContainerConfluent(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != 258 || !d.Equal(c) {
+		t.Errorf("decoded %+v with id %d, want %+v with id 258", d, id, c)
+	}
+	if _, _, err := DeserializeContainerConfluent(b[:len(b)-1]); !errors.Is(err, ErrTruncated) {
+		t.Errorf("truncated record: error = %v, want ErrTruncated", err)
+	}
+}
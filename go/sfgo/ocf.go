@@ -0,0 +1,55 @@
+package sfgo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/actgardner/gogen-avro/v7/container/avro"
+)
+
+// OCF framing constants.
+const (
+	ocfSyncSize  = 16
+	ocfSchemaKey = "avro.schema"
+	ocfCodecKey  = "avro.codec"
+)
+
+var ocfMagic = [4]byte{'O', 'b', 'j', 1}
+
+// ocfHeader holds the parsed header of an Avro object container file.
+type ocfHeader struct {
+	schema []byte
+	codec  string
+	meta   map[string][]byte
+	sync   [ocfSyncSize]byte
+}
+
+// readOCFHeader reads and checks an OCF header from r.
+func readOCFHeader(r io.Reader) (*ocfHeader, error) {
+	h, err := avro.DeserializeAvroContainerHeader(r)
+	if err != nil {
+		return nil, newDecodeError(err)
+	}
+	if h.Magic != ocfMagic {
+		return nil, &Error{Kind: ErrDecode, Err: fmt.Errorf("unexpected magic in OCF header: %q", h.Magic[:])}
+	}
+	schema, ok := h.Meta[ocfSchemaKey]
+	if !ok {
+		return nil, &Error{Kind: ErrDecode, Err: errors.New("missing avro.schema in OCF header")}
+	}
+	codec := "null"
+	if c, ok := h.Meta[ocfCodecKey]; ok {
+		codec = string(c)
+	}
+	return &ocfHeader{schema: schema, codec: codec, meta: h.Meta, sync: h.Sync}, nil
+}
+
+// decompressBlock decompresses the records of an OCF block encoded with codec.
+func decompressBlock(codec string, data []byte) ([]byte, error) {
+	c, err := getCodec(codec)
+	if err != nil {
+		return nil, err
+	}
+	return c.Decompress(data)
+}
@@ -0,0 +1,151 @@
+package sfgo
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"sync"
+
+	"github.com/golang/snappy"
+)
+
+// Codec compresses and decompresses the record bytes of OCF blocks.
+// Codecs must be safe for concurrent use.
+type Codec interface {
+	// Name returns the codec name stored in the avro.codec header entry.
+	Name() string
+	Compress(data []byte) ([]byte, error)
+	Decompress(data []byte) ([]byte, error)
+}
+
+var (
+	codecsMu sync.RWMutex
+	codecs   = map[string]Codec{}
+)
+
+func init() {
+	RegisterCodec(nullCodec{})
+	RegisterCodec(deflateCodec{})
+	RegisterCodec(snappyCodec{})
+}
+
+// RegisterCodec makes a codec available to the OCF readers and writers under its name,
+// replacing any codec previously registered with the same name.
+// The null, deflate and snappy codecs are registered by default.
+func RegisterCodec(c Codec) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+	codecs[c.Name()] = c
+}
+
+// LookupCodec returns the codec registered under name.
+func LookupCodec(name string) (Codec, bool) {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	c, ok := codecs[name]
+	return c, ok
+}
+
+// getCodec returns the codec registered under name, or an ErrUnsupportedOp error.
+func getCodec(name string) (Codec, error) {
+	c, ok := LookupCodec(name)
+	if !ok {
+		return nil, &Error{Kind: ErrUnsupportedOp, Err: fmt.Errorf("unknown OCF codec '%s' (not registered with RegisterCodec)", name)}
+	}
+	return c, nil
+}
+
+// maxOCFBlockDataSize bounds the decompressed size of OCF blocks read with the built-in codecs,
+// so that crafted blocks cannot expand into huge allocations.
+const maxOCFBlockDataSize = 256 << 20
+
+// errBlockDataTooLarge reports a block that decompresses to more than limit bytes.
+func errBlockDataTooLarge(codec string, limit int) error {
+	return &Error{Kind: ErrLimitExceeded, Err: fmt.Errorf("%s block decompresses to more than %d bytes", codec, limit)}
+}
+
+type nullCodec struct{}
+
+func (nullCodec) Name() string                           { return "null" }
+func (nullCodec) Compress(data []byte) ([]byte, error)   { return data, nil }
+func (nullCodec) Decompress(data []byte) ([]byte, error) { return data, nil }
+
+type deflateCodec struct{}
+
+func (deflateCodec) Name() string { return "deflate" }
+
+func (deflateCodec) Compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	fw, err := flate.NewWriter(&buf, flate.DefaultCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := fw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := fw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (deflateCodec) Decompress(data []byte) ([]byte, error) {
+	return inflate(data, maxOCFBlockDataSize)
+}
+
+// inflate decompresses deflate data, failing with an ErrLimitExceeded error beyond limit bytes.
+func inflate(data []byte, limit int) ([]byte, error) {
+	raw, err := ioutil.ReadAll(io.LimitReader(flate.NewReader(bytes.NewReader(data)), int64(limit)+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) > limit {
+		return nil, errBlockDataTooLarge("deflate", limit)
+	}
+	return raw, nil
+}
+
+// snappyCodec implements Avro's snappy codec, which appends the big-endian CRC32 of the
+// uncompressed data to each block. The checksum is verified on decompression.
+type snappyCodec struct{}
+
+func (snappyCodec) Name() string { return "snappy" }
+
+func (snappyCodec) Compress(data []byte) ([]byte, error) {
+	var crc [4]byte
+	binary.BigEndian.PutUint32(crc[:], crc32.ChecksumIEEE(data))
+	return append(snappy.Encode(nil, data), crc[:]...), nil
+}
+
+func (snappyCodec) Decompress(data []byte) ([]byte, error) {
+	return unsnappy(data, maxOCFBlockDataSize)
+}
+
+// unsnappy decompresses a snappy block and verifies its checksum, failing with an
+// ErrLimitExceeded error beyond limit bytes.
+func unsnappy(data []byte, limit int) ([]byte, error) {
+	if len(data) < 4 {
+		return nil, errors.New("snappy block too short")
+	}
+	data, crc := data[:len(data)-4], binary.BigEndian.Uint32(data[len(data)-4:])
+	n, err := snappy.DecodedLen(data)
+	if err != nil {
+		return nil, err
+	}
+	if n > limit {
+		return nil, errBlockDataTooLarge("snappy", limit)
+	}
+	raw, err := snappy.Decode(nil, data)
+	if err != nil {
+		return nil, err
+	}
+	if sum := crc32.ChecksumIEEE(raw); sum != crc {
+		return nil, &Error{Kind: ErrCRCMismatch, Err: fmt.Errorf("snappy block CRC32 %08x, expected %08x", sum, crc)}
+	}
+	return raw, nil
+}
@@ -0,0 +1,45 @@
+package sfgo
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestCodecDecompressLimit(t *testing.T) {
+	data := bytes.Repeat([]byte("sysflow"), 100)
+	deflated, err := deflateCodec{}.Compress(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snappied, err := snappyCodec{}.Compress(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		name       string
+		in         []byte
+		decompress func([]byte, int) ([]byte, error)
+	}{
+		{"deflate", deflated, inflate},
+		{"snappy", snappied, unsnappy},
+	} {
+		if raw, err := tc.decompress(tc.in, len(data)); err != nil || !bytes.Equal(raw, data) {
+			t.Errorf("%s at limit: got %d bytes, error %v", tc.name, len(raw), err)
+		}
+		if _, err := tc.decompress(tc.in, len(data)-1); !errors.Is(err, ErrLimitExceeded) {
+			t.Errorf("%s above limit: error = %v, want ErrLimitExceeded", tc.name, err)
+		}
+	}
+}
+
+func TestSnappyCodecCRC(t *testing.T) {
+	b, err := snappyCodec{}.Compress([]byte("sysflow"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b[len(b)-1] ^= 0xff
+	if _, err := (snappyCodec{}).Decompress(b); !errors.Is(err, ErrCRCMismatch) {
+		t.Errorf("corrupt CRC: error = %v, want ErrCRCMismatch", err)
+	}
+}
@@ -0,0 +1,97 @@
+package sfgo
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// maxOCFBlockSize bounds the compressed size of OCF blocks read from a stream, well above the
+// blocks written by ContainerOCFWriter, so that corrupt or crafted block headers cannot trigger
+// huge allocations.
+const maxOCFBlockSize = 256 << 20
+
+// ocfBlockStream reads the blocks of an OCF stream sequentially, tracking the stream offset.
+type ocfBlockStream struct {
+	src       *countingReader
+	header    *ocfHeader
+	block     *bytes.Reader
+	remaining int64
+}
+
+// newOCFBlockStream reads the OCF header from r and returns a stream positioned at the first block.
+func newOCFBlockStream(r io.Reader) (*ocfBlockStream, error) {
+	src := &countingReader{r: r}
+	header, err := readOCFHeader(src)
+	if err != nil {
+		return nil, err
+	}
+	return &ocfBlockStream{src: src, header: header}, nil
+}
+
+// next returns a reader positioned at the next record, opening blocks as needed.
+// It returns io.EOF at the end of the stream.
+func (s *ocfBlockStream) next() (*bytes.Reader, error) {
+	for s.remaining == 0 {
+		if err := s.openBlock(); err != nil {
+			return nil, err
+		}
+	}
+	s.remaining--
+	return s.block, nil
+}
+
+// openBlock reads and decompresses the next block.
+func (s *ocfBlockStream) openBlock() error {
+	count, data, err := readOCFBlock(s.src, s.header.sync)
+	if err != nil {
+		return err
+	}
+	raw, err := decompressBlock(s.header.codec, data)
+	if err != nil {
+		return newDecodeError(err)
+	}
+	s.block = bytes.NewReader(raw)
+	s.remaining = count
+	return nil
+}
+
+// readOCFBlock reads the next block from src, checking its sync marker, and returns its record
+// count and compressed data. It returns io.EOF at the end of the stream. Errors following a valid
+// block header are returned along with the declared record count.
+func readOCFBlock(src *countingReader, sync [ocfSyncSize]byte) (int64, []byte, error) {
+	off := src.n
+	count, err := readLong(src)
+	if err == io.EOF {
+		return 0, nil, io.EOF
+	} else if err != nil {
+		return 0, nil, newDecodeError(fmt.Errorf("reading block count at offset %d: %w", off, err))
+	}
+	size, err := readLong(src)
+	if err != nil {
+		return 0, nil, newDecodeError(fmt.Errorf("reading block size at offset %d: %w", off, err))
+	}
+	if count < 0 || size < 0 {
+		return 0, nil, newDecodeError(fmt.Errorf("invalid block header at offset %d", off))
+	}
+	if size > maxOCFBlockSize {
+		return count, nil, &Error{Kind: ErrDecode, Err: fmt.Errorf("block size %d at offset %d exceeds %d bytes", size, off, maxOCFBlockSize)}
+	}
+	// Read through a limited reader, so that the buffer only grows with the data actually present.
+	data, err := ioutil.ReadAll(io.LimitReader(src, size))
+	if err == nil && int64(len(data)) < size {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return count, nil, newDecodeError(fmt.Errorf("reading block at offset %d: %w", off, err))
+	}
+	var marker [ocfSyncSize]byte
+	if _, err := io.ReadFull(src, marker[:]); err != nil {
+		return count, nil, newDecodeError(fmt.Errorf("reading sync marker of block at offset %d: %w", off, err))
+	}
+	if marker != sync {
+		return count, nil, newDecodeError(fmt.Errorf("unexpected sync marker at offset %d", src.n-ocfSyncSize))
+	}
+	return count, data, nil
+}
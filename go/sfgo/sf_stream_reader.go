@@ -0,0 +1,71 @@
+package sfgo
+
+import (
+	"fmt"
+	"io"
+)
+
+// SFSchemaVersion is the most recent SysFlow schema version supported by this package.
+const SFSchemaVersion int64 = 4
+
+// SFStreamReader reads SysFlow (.sf) files, which are Avro containers of SysFlow records
+// starting with a header record.
+type SFStreamReader struct {
+	r      *SysFlowReader
+	header *SFHeader
+}
+
+// NewSFStreamReader creates a reader over a SysFlow file, reading and checking its header.
+func NewSFStreamReader(r io.Reader) (*SFStreamReader, error) {
+	sr, err := NewSysFlowReader(r)
+	if err != nil {
+		return nil, newDecodeError(err)
+	}
+	rec, err := sr.Read()
+	if err != nil {
+		return nil, newDecodeError(fmt.Errorf("reading SysFlow header: %w", err))
+	}
+	if rec.Rec == nil || rec.Rec.UnionType != RecUnionTypeEnumSFHeader || rec.Rec.SFHeader == nil {
+		return nil, &Error{Kind: ErrDecode, Err: fmt.Errorf("SysFlow stream does not start with a header record")}
+	}
+	if v := rec.Rec.SFHeader.Version; v < 1 || v > SFSchemaVersion {
+		return nil, &Error{Kind: ErrUnsupportedOp, Err: fmt.Errorf("unsupported SysFlow schema version %d", v)}
+	}
+	return &SFStreamReader{r: sr, header: rec.Rec.SFHeader}, nil
+}
+
+// Header returns the stream's header record.
+func (s *SFStreamReader) Header() *SFHeader {
+	return s.header
+}
+
+// Next returns the next SysFlow record, or io.EOF at the end of the stream.
+func (s *SFStreamReader) Next() (*SysFlow, error) {
+	rec, err := s.r.Read()
+	if err == io.EOF {
+		return nil, io.EOF
+	} else if err != nil {
+		return nil, newDecodeError(err)
+	}
+	if rec.Rec == nil {
+		return nil, &Error{Kind: ErrDecode, Err: fmt.Errorf("SysFlow record without entity")}
+	}
+	return rec, nil
+}
+
+// NextContainer returns the next container in the stream, skipping other record types,
+// or io.EOF at the end of the stream.
+func (s *SFStreamReader) NextContainer() (*Container, error) {
+	for {
+		rec, err := s.Next()
+		if err != nil {
+			return nil, err
+		}
+		if rec.Rec.UnionType == RecUnionTypeEnumContainer {
+			if err := enrichContainer(rec.Rec.Container); err != nil {
+				return nil, err
+			}
+			return rec.Rec.Container, nil
+		}
+	}
+}
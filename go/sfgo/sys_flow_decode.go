@@ -0,0 +1,42 @@
+package sfgo
+
+import (
+	"io"
+
+	"github.com/actgardner/gogen-avro/v7/vm"
+)
+
+// getSysFlowProgram returns the cached program decoding the top-level SysFlow record union. The
+// program is immutable and safe to share across goroutines.
+func getSysFlowProgram() (*vm.Program, error) {
+	return getEntityProgram(NewSysFlow())
+}
+
+// DeserializeSysFlowCached decodes a SysFlow record from r like DeserializeSysFlow, but compiles
+// the decoding program only once and reuses it, which matters when decoding many records: the
+// generated DeserializeSysFlow compiles the program on every call. Errors wrap the package's
+// sentinel errors, and a clean end of input is returned as io.EOF.
+func DeserializeSysFlowCached(r io.Reader) (*SysFlow, error) {
+	prog, err := getSysFlowProgram()
+	if err != nil {
+		return nil, err
+	}
+	t := NewSysFlow()
+	if err := evalEntity(r, prog, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// WarmUpDecoders compiles and caches the SysFlow and container decoding programs,
+// so that the first decode calls do not pay the compilation cost.
+func WarmUpDecoders() error {
+	if _, err := getSysFlowProgram(); err != nil {
+		return err
+	}
+	if _, err := getContainerProgram(); err != nil {
+		return err
+	}
+	_, err := getRecSkipPrograms()
+	return err
+}
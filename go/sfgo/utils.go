@@ -353,7 +353,7 @@ func GetEvtTypes(opFlags int32, rtype SFObjectType) []string {
 func GetOpenFlags(flag int64) []string {
 	var flags = make([]string, 0)
 	cache := getCache()
-	if v, ok := cache.openFlags.Get(string(flag)); ok { //nolint:govet
+	if v, ok := cache.openFlags.Get(strconv.FormatInt(flag, 10)); ok {
 		return v.([]string)
 	}
 	if flag == O_NONE {
@@ -401,7 +401,7 @@ func GetOpenFlags(flag int64) []string {
 	if flag&O_SYNC == O_SYNC {
 		flags = append(flags, OpenFlagSync)
 	}
-	cache.openFlags.Set(string(flag), flags) //nolint:govet
+	cache.openFlags.Set(strconv.FormatInt(flag, 10), flags)
 	return flags
 }
 
@@ -439,7 +439,7 @@ func GetProto(iana int64) string {
 
 // GetFileType returns the string representation of a ASCII file type.
 func GetFileType(t int64) string {
-	return string(t) //nolint:govet
+	return string(rune(t))
 }
 
 // GetSockFamily returns the sock family of a socket descriptor.
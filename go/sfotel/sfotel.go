@@ -0,0 +1,91 @@
+//
+// Copyright (C) 2022 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sfotel converts SysFlow entities to OpenTelemetry pdata, kept apart from sfgo so that
+// only importers depend on the OpenTelemetry collector libraries.
+package sfotel
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// ScopeName is the instrumentation scope of the log records built by this package.
+const ScopeName = "github.com/sysflow-telemetry/sf-apis/go/sfotel"
+
+// ContainersToOTelLogs returns one log record per container, all under a single resource and
+// scope, in the order of cs. The attributes are those of OTelAttributes, the body describes the
+// observed container, and the observed timestamp is the time of the call. Nil containers are
+// skipped.
+func ContainersToOTelLogs(cs []*sfgo.Container) plog.Logs {
+	logs := plog.NewLogs()
+	sl := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	sl.Scope().SetName(ScopeName)
+	records := sl.LogRecords()
+	records.EnsureCapacity(len(cs))
+	now := pcommon.NewTimestampFromTime(time.Now())
+	for _, c := range cs {
+		if c == nil {
+			continue
+		}
+		lr := records.AppendEmpty()
+		lr.SetObservedTimestamp(now)
+		lr.SetSeverityNumber(plog.SeverityNumberINFO)
+		lr.Body().SetStringVal(describe(c))
+		putAttributes(lr.Attributes(), c.OTelAttributes())
+	}
+	return logs
+}
+
+// describe returns the log body of a container observation.
+func describe(c *sfgo.Container) string {
+	return fmt.Sprintf("container %s observed: name=%q image=%q runtime=%s", c.Id, c.Name, c.Image, c.Type.FalcoType())
+}
+
+// putAttributes copies the OTelAttributes values, which are strings, bools or string slices, into m
+// in key order.
+func putAttributes(m pcommon.Map, attrs map[string]interface{}) {
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	m.EnsureCapacity(len(keys))
+	for _, k := range keys {
+		switch v := attrs[k].(type) {
+		case string:
+			m.UpsertString(k, v)
+		case bool:
+			m.UpsertBool(k, v)
+		case []string:
+			sv := pcommon.NewValueSlice()
+			s := sv.SliceVal()
+			s.EnsureCapacity(len(v))
+			for _, e := range v {
+				s.AppendEmpty().SetStringVal(e)
+			}
+			m.Upsert(k, sv)
+		}
+	}
+}
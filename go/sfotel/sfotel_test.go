@@ -0,0 +1,37 @@
+package sfotel
+
+import (
+	"testing"
+
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+)
+
+func TestContainersToOTelLogs(t *testing.T) {
+	c := sfgo.NewContainer()
+	c.Id, c.Name, c.Image, c.Type, c.Privileged = "2f3b", "web", "nginx:1.21", sfgo.ContainerTypeCT_CRIO, true
+	logs := ContainersToOTelLogs([]*sfgo.Container{c, nil, sfgo.NewContainer()})
+	if n := logs.LogRecordCount(); n != 2 {
+		t.Fatalf("LogRecordCount = %d, want 2", n)
+	}
+	sl := logs.ResourceLogs().At(0).ScopeLogs().At(0)
+	if sl.Scope().Name() != ScopeName {
+		t.Errorf("scope name = %q, want %q", sl.Scope().Name(), ScopeName)
+	}
+	lr := sl.LogRecords().At(0)
+	if want := `container 2f3b observed: name="web" image="nginx:1.21" runtime=cri-o`; lr.Body().StringVal() != want {
+		t.Errorf("body = %q, want %q", lr.Body().StringVal(), want)
+	}
+	attrs := lr.Attributes()
+	if v, ok := attrs.Get(sfgo.OTelContainerID); !ok || v.StringVal() != "2f3b" {
+		t.Errorf("%s = %v", sfgo.OTelContainerID, v.AsString())
+	}
+	if v, ok := attrs.Get(sfgo.OTelContainerPrivileged); !ok || !v.BoolVal() {
+		t.Errorf("%s = %v", sfgo.OTelContainerPrivileged, v.AsString())
+	}
+	if v, ok := attrs.Get(sfgo.OTelContainerImageTags); !ok || v.SliceVal().Len() != 1 || v.SliceVal().At(0).StringVal() != "1.21" {
+		t.Errorf("%s = %v", sfgo.OTelContainerImageTags, v.AsString())
+	}
+	if attrs.Len() != len(c.OTelAttributes()) {
+		t.Errorf("got %d attributes, want %d", attrs.Len(), len(c.OTelAttributes()))
+	}
+}
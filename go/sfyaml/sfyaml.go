@@ -0,0 +1,83 @@
+//
+// Copyright (C) 2022 IBM Corporation.
+//
+// Authors:
+// Frederico Araujo <frederico.araujo@ibm.com>
+// Teryl Taylor <terylt@ibm.com>
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sfyaml implements YAML support for SysFlow entities, kept apart from sfgo so that only
+// importers depend on a YAML library.
+package sfyaml
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sysflow-telemetry/sf-apis/go/sfgo"
+	"gopkg.in/yaml.v2"
+)
+
+// containerSpec mirrors the YAML layout of a container, which uses the Avro field names.
+type containerSpec struct {
+	ID         *string `yaml:"id"`
+	Name       string  `yaml:"name"`
+	Image      *string `yaml:"image"`
+	ImageID    string  `yaml:"imageid"`
+	Type       *string `yaml:"type"`
+	Privileged bool    `yaml:"privileged"`
+	PodID      *string `yaml:"podId"`
+}
+
+// UnmarshalContainerYAML parses a container from YAML, with the Avro field names as keys and the
+// type given as enum symbol (e.g., CT_DOCKER), and validates it with Validate. The keys id, image
+// and type are required, podId may be omitted or null; unknown keys are rejected. Errors name the
+// offending key, and the line for syntax errors and unknown keys.
+func UnmarshalContainerYAML(b []byte) (*sfgo.Container, error) {
+	var spec containerSpec
+	if err := yaml.UnmarshalStrict(b, &spec); err != nil {
+		return nil, fmt.Errorf("invalid container YAML: %w", err)
+	}
+	var missing []string
+	if spec.ID == nil {
+		missing = append(missing, "'id'")
+	}
+	if spec.Image == nil {
+		missing = append(missing, "'image'")
+	}
+	if spec.Type == nil {
+		missing = append(missing, "'type'")
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("invalid container YAML: missing required keys %s", strings.Join(missing, ", "))
+	}
+	t, err := sfgo.NewContainerTypeValue(*spec.Type)
+	if err != nil {
+		return nil, fmt.Errorf("invalid container YAML: key 'type': %w", err)
+	}
+	c := sfgo.NewContainer()
+	c.Id = *spec.ID
+	c.Name = spec.Name
+	c.Image = *spec.Image
+	c.Imageid = spec.ImageID
+	c.Type = t
+	c.Privileged = spec.Privileged
+	if spec.PodID != nil {
+		c.PodId = &sfgo.PodIdUnion{UnionType: sfgo.PodIdUnionTypeEnumString, String: *spec.PodID}
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}